@@ -0,0 +1,221 @@
+package xpad
+
+// Button is a logical controller button, independent of the evdev code the
+// driver reports it with. The first 17 values follow the W3C Gamepad
+// "standard" button order.
+type Button uint8
+
+const (
+	ButtonA Button = iota
+	ButtonB
+	ButtonX
+	ButtonY
+	ButtonLeftBumper
+	ButtonRightBumper
+	ButtonLeftTrigger
+	ButtonRightTrigger
+	ButtonBack
+	ButtonStart
+	ButtonLeftStick
+	ButtonRightStick
+	ButtonDpadUp
+	ButtonDpadDown
+	ButtonDpadLeft
+	ButtonDpadRight
+	ButtonGuide
+	ButtonShare
+	// ButtonPaddle1 to ButtonPaddle4 are the Elite paddles P1 (upper right),
+	// P2 (lower right), P3 (upper left) and P4 (lower left).
+	ButtonPaddle1
+	ButtonPaddle2
+	ButtonPaddle3
+	ButtonPaddle4
+
+	// ButtonCount is the number of logical buttons.
+	ButtonCount = iota
+)
+
+// buttonNames holds the generic names, as printed on Xbox 360 pads.
+var buttonNames = [ButtonCount]string{
+	ButtonA:            "A",
+	ButtonB:            "B",
+	ButtonX:            "X",
+	ButtonY:            "Y",
+	ButtonLeftBumper:   "LB",
+	ButtonRightBumper:  "RB",
+	ButtonLeftTrigger:  "LT",
+	ButtonRightTrigger: "RT",
+	ButtonBack:         "Back",
+	ButtonStart:        "Start",
+	ButtonLeftStick:    "LS",
+	ButtonRightStick:   "RS",
+	ButtonDpadUp:       "D-pad Up",
+	ButtonDpadDown:     "D-pad Down",
+	ButtonDpadLeft:     "D-pad Left",
+	ButtonDpadRight:    "D-pad Right",
+	ButtonGuide:        "Guide",
+	ButtonShare:        "Share",
+	ButtonPaddle1:      "P1",
+	ButtonPaddle2:      "P2",
+	ButtonPaddle3:      "P3",
+	ButtonPaddle4:      "P4",
+}
+
+// String returns the generic button name.
+func (b Button) String() string {
+	if b < ButtonCount {
+		return buttonNames[b]
+	}
+	return "unknown"
+}
+
+// Name returns the button name as printed on controllers of the family.
+func (b Button) Name(family ControllerFamily) string {
+	switch family {
+	case FamilyXbox:
+		switch b {
+		case ButtonLeftBumper:
+			return "White"
+		case ButtonRightBumper:
+			return "Black"
+		}
+	case FamilyXboxOne, FamilyXboxSeries:
+		switch b {
+		case ButtonBack:
+			return "View"
+		case ButtonStart:
+			return "Menu"
+		case ButtonGuide:
+			return "Xbox"
+		}
+	}
+	return b.String()
+}
+
+// MapButton returns the logical button for an EV_KEY code as the xpad driver
+// reports it for the family. D-pad buttons are mapped in both the BTNDPad*
+// and BTNTriggerHappy1-4 forms; a hat D-pad needs a DpadDecoder instead.
+func MapButton(family ControllerFamily, code uint16) (Button, bool) {
+	switch code {
+	case BTNA:
+		return ButtonA, true
+	case BTNB:
+		return ButtonB, true
+	case BTNX:
+		return ButtonX, true
+	case BTNY:
+		return ButtonY, true
+	case BTNTL:
+		return ButtonLeftBumper, true
+	case BTNTR:
+		return ButtonRightBumper, true
+	case BTNTL2:
+		return ButtonLeftTrigger, true
+	case BTNTR2:
+		return ButtonRightTrigger, true
+	case BTNSelect:
+		return ButtonBack, true
+	case BTNStart:
+		return ButtonStart, true
+	case BTNMode:
+		return ButtonGuide, true
+	case BTNThumbL:
+		return ButtonLeftStick, true
+	case BTNThumbR:
+		return ButtonRightStick, true
+	case BTNDPadUp, BTNTriggerHappy3:
+		return ButtonDpadUp, true
+	case BTNDPadDown, BTNTriggerHappy4:
+		return ButtonDpadDown, true
+	case BTNDPadLeft, BTNTriggerHappy1:
+		return ButtonDpadLeft, true
+	case BTNDPadRight, BTNTriggerHappy2:
+		return ButtonDpadRight, true
+	case KeyRecord:
+		return ButtonShare, true
+	case BTNTriggerHappy5:
+		return ButtonPaddle1, true
+	case BTNTriggerHappy6:
+		return ButtonPaddle2, true
+	case BTNTriggerHappy7:
+		return ButtonPaddle3, true
+	case BTNTriggerHappy8:
+		return ButtonPaddle4, true
+	}
+	// The original Xbox pad has White and Black buttons where later pads
+	// have bumpers.
+	if family == FamilyXbox {
+		switch code {
+		case BTNZ:
+			return ButtonLeftBumper, true
+		case BTNC:
+			return ButtonRightBumper, true
+		}
+	}
+	return 0, false
+}
+
+// Axis is a logical controller axis. The first four values follow the W3C
+// Gamepad "standard" axis order.
+type Axis uint8
+
+const (
+	AxisLeftX Axis = iota
+	AxisLeftY
+	AxisRightX
+	AxisRightY
+	AxisLeftTrigger
+	AxisRightTrigger
+
+	// AxisCount is the number of logical axes.
+	AxisCount = iota
+)
+
+var axisNames = [AxisCount]string{
+	AxisLeftX:        "Left Stick X",
+	AxisLeftY:        "Left Stick Y",
+	AxisRightX:       "Right Stick X",
+	AxisRightY:       "Right Stick Y",
+	AxisLeftTrigger:  "LT",
+	AxisRightTrigger: "RT",
+}
+
+// String returns the axis name.
+func (a Axis) String() string {
+	if a < AxisCount {
+		return axisNames[a]
+	}
+	return "unknown"
+}
+
+// MapAxis returns the logical axis for an EV_ABS code. The hat axes are not
+// mapped; decode them with a DpadDecoder.
+func MapAxis(code uint16) (Axis, bool) {
+	switch code {
+	case ABSX:
+		return AxisLeftX, true
+	case ABSY:
+		return AxisLeftY, true
+	case ABSRX:
+		return AxisRightX, true
+	case ABSRY:
+		return AxisRightY, true
+	case ABSZ:
+		return AxisLeftTrigger, true
+	case ABSRZ:
+		return AxisRightTrigger, true
+	default:
+		return 0, false
+	}
+}
+
+// ButtonName returns the name of an EV_KEY code as printed on the device, or
+// "" if the code is not a controller button.
+func (d DeviceInfo) ButtonName(code uint16) string {
+	family := d.Family()
+	b, ok := MapButton(family, code)
+	if !ok {
+		return ""
+	}
+	return b.Name(family)
+}
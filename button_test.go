@@ -0,0 +1,68 @@
+package xpad
+
+import "testing"
+
+func TestMapButton(t *testing.T) {
+	cases := []struct {
+		family ControllerFamily
+		code   uint16
+		want   Button
+		name   string
+		ok     bool
+	}{
+		{FamilyXbox360, BTNA, ButtonA, "A", true},
+		{FamilyXbox360, BTNSelect, ButtonBack, "Back", true},
+		{FamilyXboxOne, BTNSelect, ButtonBack, "View", true},
+		{FamilyXboxSeries, BTNStart, ButtonStart, "Menu", true},
+		{FamilyXbox360Wireless, BTNMode, ButtonGuide, "Guide", true},
+		{FamilyXboxOne, BTNMode, ButtonGuide, "Xbox", true},
+		{FamilyXboxSeries, KeyRecord, ButtonShare, "Share", true},
+		{FamilyXboxOne, BTNTriggerHappy5, ButtonPaddle1, "P1", true},
+		{FamilyXboxOne, BTNTriggerHappy8, ButtonPaddle4, "P4", true},
+		{FamilyXbox360Wireless, BTNTriggerHappy1, ButtonDpadLeft, "D-pad Left", true},
+		{FamilyXbox360, BTNDPadUp, ButtonDpadUp, "D-pad Up", true},
+		{FamilyXbox360, BTNTR2, ButtonRightTrigger, "RT", true},
+		{FamilyXbox, BTNZ, ButtonLeftBumper, "White", true},
+		{FamilyXbox, BTNC, ButtonRightBumper, "Black", true},
+		{FamilyXbox360, BTNC, 0, "", false},
+		{FamilyXbox360, KeyMax, 0, "", false},
+	}
+	for _, tc := range cases {
+		got, ok := MapButton(tc.family, tc.code)
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("MapButton(%v, %#x) = %v, %v, want %v, %v", tc.family, tc.code, got, ok, tc.want, tc.ok)
+		}
+		if ok && got.Name(tc.family) != tc.name {
+			t.Fatalf("%v.Name(%v) = %q, want %q", got, tc.family, got.Name(tc.family), tc.name)
+		}
+	}
+}
+
+func TestMapAxis(t *testing.T) {
+	cases := []struct {
+		code uint16
+		want Axis
+		ok   bool
+	}{
+		{ABSX, AxisLeftX, true},
+		{ABSRY, AxisRightY, true},
+		{ABSZ, AxisLeftTrigger, true},
+		{ABSRZ, AxisRightTrigger, true},
+		{ABSHat0X, 0, false},
+	}
+	for _, tc := range cases {
+		if got, ok := MapAxis(tc.code); got != tc.want || ok != tc.ok {
+			t.Fatalf("MapAxis(%#x) = %v, %v, want %v, %v", tc.code, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestDeviceInfoButtonName(t *testing.T) {
+	info := DeviceInfo{Name: "Microsoft Xbox Series S|X Controller", VendorID: 0x045e, ProductID: 0x0b12}
+	if got := info.ButtonName(BTNSelect); got != "View" {
+		t.Fatalf("ButtonName(BTNSelect) = %q, want %q", got, "View")
+	}
+	if got := info.ButtonName(ABSX); got != "" {
+		t.Fatalf("ButtonName(ABSX) = %q, want empty", got)
+	}
+}
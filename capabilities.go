@@ -0,0 +1,201 @@
+package xpad
+
+import (
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Capabilities holds the event types, codes and properties a device
+// advertises. Bitsets use the same layout as Device.EventTypes.
+type Capabilities struct {
+	Types      []byte
+	Codes      map[EventKind][]byte
+	Properties []byte
+}
+
+// HasEventType reports whether the event type is advertised.
+func (c Capabilities) HasEventType(ev EventKind) bool {
+	return bitsetHas(c.Types, uint16(ev))
+}
+
+// HasEventCode reports whether the event code is advertised.
+func (c Capabilities) HasEventCode(ev EventKind, code uint16) bool {
+	return bitsetHas(c.Codes[ev], code)
+}
+
+// HasProperty reports whether the input property (INPUT_PROP_*) is set.
+func (c Capabilities) HasProperty(prop uint16) bool {
+	return bitsetHas(c.Properties, prop)
+}
+
+// EventCodes lists the advertised codes for an event type in ascending order.
+func (c Capabilities) EventCodes(ev EventKind) []uint16 {
+	return bitsetCodes(c.Codes[ev])
+}
+
+// IsGamepad reports whether the capabilities look like a gamepad: the
+// BTN_GAMEPAD key range plus at least one absolute axis.
+func (c Capabilities) IsGamepad() bool {
+	return c.HasEventCode(EVKey, BTNGamepad) && c.HasEventType(EVAbs)
+}
+
+func (c *Capabilities) setCode(ev EventKind, code uint16) {
+	if c.Codes == nil {
+		c.Codes = make(map[EventKind][]byte)
+	}
+	c.Codes[ev] = bitsetSet(c.Codes[ev], code)
+}
+
+// Modalias is a decoded input modalias string, as found in
+// /sys/class/input/eventX/device/modalias.
+type Modalias struct {
+	BusType      uint16
+	Vendor       uint16
+	Product      uint16
+	Version      uint16
+	Capabilities Capabilities
+}
+
+// modaliasSections maps modalias list prefixes to event types.
+var modaliasSections = map[byte]EventKind{
+	'e': EVSyn,
+	'k': EVKey,
+	'r': EVRel,
+	'a': EVAbs,
+	'm': EVMsc,
+	'l': EVLed,
+	's': EVSnd,
+	'f': EVFF,
+	'w': EVSw,
+}
+
+// ParseModalias decodes an input modalias such as
+// "input:b0003v045Ep028Ee0114-e0,1,3,15,k130,131,ra0,1,mlsfw".
+func ParseModalias(text string) (Modalias, error) {
+	text = strings.TrimSpace(text)
+	rest, ok := strings.CutPrefix(text, "input:")
+	if !ok {
+		return Modalias{}, fmt.Errorf("xpad: modalias %q is not an input modalias", text)
+	}
+	header, lists, ok := strings.Cut(rest, "-")
+	if !ok {
+		return Modalias{}, fmt.Errorf("xpad: modalias %q has no capability lists", text)
+	}
+
+	var alias Modalias
+	fields := []struct {
+		prefix byte
+		dst    *uint16
+	}{
+		{'b', &alias.BusType},
+		{'v', &alias.Vendor},
+		{'p', &alias.Product},
+		{'e', &alias.Version},
+	}
+	for _, field := range fields {
+		if len(header) < 5 || header[0] != field.prefix {
+			return Modalias{}, fmt.Errorf("xpad: modalias %q: malformed %c field", text, field.prefix)
+		}
+		value, err := strconv.ParseUint(header[1:5], 16, 16)
+		if err != nil {
+			return Modalias{}, fmt.Errorf("xpad: modalias %q: %w", text, err)
+		}
+		*field.dst = uint16(value)
+		header = header[5:]
+	}
+
+	kind, current := EventKind(0), false
+	for len(lists) > 0 {
+		if next, ok := modaliasSections[lists[0]]; ok {
+			kind, current = next, true
+			lists = lists[1:]
+			continue
+		}
+		if !current {
+			return Modalias{}, fmt.Errorf("xpad: modalias %q: code outside a capability list", text)
+		}
+		token, remaining, _ := strings.Cut(lists, ",")
+		lists = remaining
+		value, err := strconv.ParseUint(token, 16, 16)
+		if err != nil {
+			return Modalias{}, fmt.Errorf("xpad: modalias %q: %w", text, err)
+		}
+		if kind == EVSyn {
+			alias.Capabilities.Types = bitsetSet(alias.Capabilities.Types, uint16(value))
+		} else {
+			alias.Capabilities.setCode(kind, uint16(value))
+		}
+	}
+	return alias, nil
+}
+
+// capabilityFiles maps sysfs capabilities/* file names to event types.
+var capabilityFiles = []struct {
+	name string
+	kind EventKind
+}{
+	{name: "key", kind: EVKey},
+	{name: "rel", kind: EVRel},
+	{name: "abs", kind: EVAbs},
+	{name: "msc", kind: EVMsc},
+	{name: "sw", kind: EVSw},
+	{name: "led", kind: EVLed},
+	{name: "snd", kind: EVSnd},
+	{name: "ff", kind: EVFF},
+}
+
+// parseSysfsBitmap decodes a sysfs capability bitmap: space separated hex
+// words of the kernel's long size, most significant word first.
+func parseSysfsBitmap(text string) ([]byte, error) {
+	words := strings.Fields(text)
+	wordBytes := bits.UintSize / 8
+	out := make([]byte, 0, len(words)*wordBytes)
+	for i := len(words) - 1; i >= 0; i-- {
+		value, err := strconv.ParseUint(words[i], 16, bits.UintSize)
+		if err != nil {
+			return nil, fmt.Errorf("xpad: malformed capability bitmap %q: %w", text, err)
+		}
+		for b := 0; b < wordBytes; b++ {
+			out = append(out, byte(value>>(8*b)))
+		}
+	}
+	return out, nil
+}
+
+func bitsetBytes(max uint16) int {
+	return int(max/8) + 1
+}
+
+func bitsetHas(set []byte, code uint16) bool {
+	index := int(code / 8)
+	if index < 0 || index >= len(set) {
+		return false
+	}
+	mask := byte(1 << (code % 8))
+	return set[index]&mask != 0
+}
+
+func bitsetSet(set []byte, code uint16) []byte {
+	index := int(code / 8)
+	if index >= len(set) {
+		grown := make([]byte, index+1)
+		copy(grown, set)
+		set = grown
+	}
+	set[index] |= 1 << (code % 8)
+	return set
+}
+
+func bitsetCodes(set []byte) []uint16 {
+	var codes []uint16
+	for index, b := range set {
+		for bit := 0; bit < 8; bit++ {
+			if b&(1<<bit) != 0 {
+				codes = append(codes, uint16(index*8+bit))
+			}
+		}
+	}
+	return codes
+}
@@ -0,0 +1,73 @@
+package xpad
+
+import (
+	"math/bits"
+	"reflect"
+	"testing"
+)
+
+func TestParseModalias(t *testing.T) {
+	alias, err := ParseModalias("input:b0003v045Ep028Ee0114-e0,1,3,15,k130,131,133,134,136,137,13A,13B,13C,13D,13E,ra0,1,2,3,4,5,10,11,mlsf50,51,58,59,5A,60,w\n")
+	if err != nil {
+		t.Fatalf("ParseModalias() error: %v", err)
+	}
+	if alias.BusType != 0x3 || alias.Vendor != 0x045e || alias.Product != 0x028e || alias.Version != 0x0114 {
+		t.Fatalf("ParseModalias() ids = %04x:%04x:%04x:%04x", alias.BusType, alias.Vendor, alias.Product, alias.Version)
+	}
+
+	caps := alias.Capabilities
+	for _, ev := range []EventKind{EVSyn, EVKey, EVAbs, EVFF} {
+		if !caps.HasEventType(ev) {
+			t.Fatalf("HasEventType(%d) = false, want true", ev)
+		}
+	}
+	if caps.HasEventType(EVRel) {
+		t.Fatalf("HasEventType(EVRel) = true, want false")
+	}
+	if !caps.HasEventCode(EVKey, BTNMode) || caps.HasEventCode(EVKey, BTNTL2) {
+		t.Fatalf("unexpected key capabilities %v", caps.EventCodes(EVKey))
+	}
+	wantAbs := []uint16{ABSX, ABSY, ABSZ, ABSRX, ABSRY, ABSRZ, ABSHat0X, ABSHat0Y}
+	if got := caps.EventCodes(EVAbs); !reflect.DeepEqual(got, wantAbs) {
+		t.Fatalf("EventCodes(EVAbs) = %v, want %v", got, wantAbs)
+	}
+	if !caps.HasEventCode(EVFF, FFRumble) {
+		t.Fatalf("HasEventCode(EVFF, FFRumble) = false, want true")
+	}
+	if !caps.IsGamepad() {
+		t.Fatalf("IsGamepad() = false, want true")
+	}
+
+	for _, bad := range []string{"usb:v045Ep028E", "input:b0003v045E", "input:b0003v045Ep028Ee0114-130"} {
+		if _, err := ParseModalias(bad); err == nil {
+			t.Fatalf("ParseModalias(%q) should fail", bad)
+		}
+	}
+}
+
+func TestParseSysfsBitmap(t *testing.T) {
+	if bits.UintSize != 64 {
+		t.Skip("fixture bitmaps use 64-bit words")
+	}
+
+	key, err := parseSysfsBitmap("7cdb000000000000 0 0 0 0")
+	if err != nil {
+		t.Fatalf("parseSysfsBitmap(key) error: %v", err)
+	}
+	want := []uint16{BTNA, BTNB, BTNX, BTNY, BTNTL, BTNTR, BTNSelect, BTNStart, BTNMode, BTNThumbL, BTNThumbR}
+	if got := bitsetCodes(key); !reflect.DeepEqual(got, want) {
+		t.Fatalf("key codes = %#x, want %#x", got, want)
+	}
+
+	abs, err := parseSysfsBitmap("3003f")
+	if err != nil {
+		t.Fatalf("parseSysfsBitmap(abs) error: %v", err)
+	}
+	if !bitsetHas(abs, ABSHat0Y) || bitsetHas(abs, ABSProfile) {
+		t.Fatalf("abs codes = %#x", bitsetCodes(abs))
+	}
+
+	if _, err := parseSysfsBitmap("zz 0"); err == nil {
+		t.Fatalf("parseSysfsBitmap() should reject non-hex words")
+	}
+}
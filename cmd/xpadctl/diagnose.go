@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	xpad "github.com/roryl23/xpad-go"
+)
+
+func runDiagnose(args []string) error {
+	fs := flag.NewFlagSet("diagnose", flag.ContinueOnError)
+	root := fs.String("root", "", "read /dev and /sys below this directory")
+	asJSON := fs.Bool("json", false, "print the report as JSON")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	report, err := xpad.NewDiscoverer(*root).Diagnose()
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		fmt.Print(report)
+	}
+	if !report.OK() {
+		return errFailedChecks
+	}
+	return nil
+}
+
+// errFailedChecks reports that diagnose found failures; the report already
+// explains them.
+var errFailedChecks = errors.New("diagnostic checks failed")
@@ -0,0 +1,19 @@
+package main
+
+import "testing"
+
+func TestDiffLines(t *testing.T) {
+	cases := []struct {
+		current, proposed, want string
+	}{
+		{"a\nb\n", "a\nb\n", ""},
+		{"", "a\n", "+a\n"},
+		{"a\nb\nc\n", "a\nc\nd\n", " a\n-b\n c\n+d\n"},
+		{"a\nold\n", "a\nnew\n", " a\n-old\n+new\n"},
+	}
+	for _, tc := range cases {
+		if got := diffLines(tc.current, tc.proposed); got != tc.want {
+			t.Fatalf("diffLines(%q, %q) = %q, want %q", tc.current, tc.proposed, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	xpad "github.com/roryl23/xpad-go"
+)
+
+func runGamepad(args []string) error {
+	fs := flag.NewFlagSet("gamepad", flag.ContinueOnError)
+	root := fs.String("root", "", "read /dev and /sys below this directory")
+	device := fs.String("device", "", "event node to read (default: first xpad controller)")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	infos, err := xpad.NewDiscoverer(*root).FindXpadDevices()
+	if err != nil {
+		return err
+	}
+	var info xpad.DeviceInfo
+	for _, candidate := range infos {
+		if *device == "" || candidate.Path == *device {
+			info = candidate
+			break
+		}
+	}
+	if info.Path == "" {
+		return xpad.ErrNotFound
+	}
+
+	dev, err := xpad.OpenDeviceWithOptions(info, xpad.Options{Access: xpad.AccessReadOnly})
+	if err != nil {
+		return err
+	}
+	defer dev.Close()
+	tracker, err := dev.GamepadTracker(info.Family())
+	if err != nil {
+		return err
+	}
+
+	// Print one standard Gamepad object per input frame that changed
+	// anything, and a final disconnected one when the device goes away.
+	enc := json.NewEncoder(os.Stdout)
+	changed := true
+	for {
+		ev, err := dev.ReadEvent(-1)
+		if err != nil {
+			gamepad := tracker.State().Standard(info.Name, 0)
+			gamepad.Connected = false
+			if encErr := enc.Encode(gamepad); encErr != nil {
+				return encErr
+			}
+			if errors.Is(err, xpad.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("read %s: %w", info.Path, err)
+		}
+		if len(tracker.Apply(ev)) > 0 {
+			changed = true
+		}
+		// After a buffer overrun the events since SYN_DROPPED are lost;
+		// read the state back from the device.
+		if tracker.Stale() {
+			changes, err := dev.SyncGamepadTracker(tracker)
+			if err != nil {
+				return fmt.Errorf("resync %s: %w", info.Path, err)
+			}
+			if len(changes) > 0 {
+				changed = true
+			}
+		}
+		if ev.Kind == xpad.EVSyn && ev.Code == xpad.SynReport && changed {
+			if err := enc.Encode(tracker.State().Standard(info.Name, 0)); err != nil {
+				return err
+			}
+			changed = false
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Command xpadctl inspects and configures xpad controllers.
+//
+// Usage:
+//
+//	xpadctl diagnose [-json]
+//	xpadctl gamepad [-device path]
+//	xpadctl udev-rules [-group name] [-all] [-tmpfiles] [-diff file]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "diagnose":
+		err = runDiagnose(os.Args[2:])
+	case "gamepad":
+		err = runGamepad(os.Args[2:])
+	case "udev-rules":
+		err = runUdevRules(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		usage()
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "xpadctl: unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	if errors.Is(err, errDrift) || errors.Is(err, errFailedChecks) {
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "xpadctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage: xpadctl <command> [flags]
+
+commands:
+  diagnose     check the module, controllers and permissions, with hints
+  gamepad      stream controller state as W3C Gamepad "standard" JSON lines
+  udev-rules   print udev rules (or a tmpfiles.d snippet) granting controller access`)
+}
+
+// errDrift reports that a -diff comparison found differences.
+var errDrift = errors.New("existing file differs")
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	fs.SetOutput(os.Stderr)
+	return fs.Parse(args)
+}
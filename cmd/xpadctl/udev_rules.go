@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	xpad "github.com/roryl23/xpad-go"
+)
+
+func runUdevRules(args []string) error {
+	fs := flag.NewFlagSet("udev-rules", flag.ContinueOnError)
+	root := fs.String("root", "", "read /dev and /sys below this directory")
+	group := fs.String("group", "", "grant access to this group instead of uaccess")
+	nodeMode := fs.String("node-mode", "0660", "mode of event and js nodes")
+	sysfsMode := fs.String("sysfs-mode", "0664", "mode of LED and module parameter files")
+	all := fs.Bool("all", false, "cover every device in the xpad device table, not just connected ones")
+	tmpfiles := fs.Bool("tmpfiles", false, "print a tmpfiles.d snippet for module parameters instead")
+	diff := fs.String("diff", "", "dry run: print a diff against this existing file and exit 1 if it differs")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	cfg := xpad.PermissionConfig{Group: *group}
+	var err error
+	if cfg.NodeMode, err = parseMode(*nodeMode); err != nil {
+		return err
+	}
+	if cfg.SysfsMode, err = parseMode(*sysfsMode); err != nil {
+		return err
+	}
+
+	var output string
+	if *tmpfiles {
+		if output, err = xpad.GenerateTmpfiles(cfg); err != nil {
+			return err
+		}
+	} else {
+		devices, err := ruleDevices(xpad.NewDiscoverer(*root), *all)
+		if err != nil {
+			return err
+		}
+		if output, err = xpad.GenerateUdevRules(devices, cfg); err != nil {
+			return err
+		}
+	}
+
+	if *diff == "" {
+		fmt.Print(output)
+		return nil
+	}
+	current, err := os.ReadFile(*diff)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	changes := diffLines(string(current), output)
+	if changes == "" {
+		fmt.Fprintf(os.Stderr, "%s is up to date\n", *diff)
+		return nil
+	}
+	fmt.Printf("--- %s\n+++ generated\n%s", *diff, changes)
+	return errDrift
+}
+
+func ruleDevices(d *xpad.Discoverer, all bool) ([]xpad.DeviceInfo, error) {
+	if all {
+		var devices []xpad.DeviceInfo
+		for _, known := range xpad.KnownDevices() {
+			devices = append(devices, xpad.DeviceInfo{
+				Name:      known.Name,
+				BusType:   xpad.BusUSB,
+				VendorID:  known.VendorID,
+				ProductID: known.ProductID,
+			})
+		}
+		return devices, nil
+	}
+	devices, err := d.FindXpadDevices()
+	if err != nil {
+		return nil, err
+	}
+	if len(devices) == 0 {
+		return nil, fmt.Errorf("no controllers found; connect one or use -all")
+	}
+	return devices, nil
+}
+
+func parseMode(text string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(text, 8, 32)
+	if err != nil || mode > 0o7777 {
+		return 0, fmt.Errorf("invalid mode %q", text)
+	}
+	return os.FileMode(mode), nil
+}
@@ -0,0 +1,115 @@
+package xpad
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Controller groups the kernel nodes the xpad driver creates for one physical
+// pad: the event device, joystick, LED, hidraw node and power supply.
+type Controller struct {
+	// Info describes the event device.
+	Info DeviceInfo
+	// InterfacePath is the sysfs directory of the USB interface the nodes share.
+	InterfacePath string
+	// HidrawPath is the /dev/hidrawN node on the same interface, if any.
+	HidrawPath string
+	// PowerSupplyPath is the sysfs power_supply directory, if any.
+	PowerSupplyPath string
+
+	Device      *Device
+	Joystick    *Joystick
+	LED         *LED
+	Hidraw      *os.File
+	PowerSupply *PowerSupply
+}
+
+// Open opens every node of the controller. If any of them fails, the nodes
+// opened so far are closed and the error is returned.
+func (c *Controller) Open() error {
+	if c.Device != nil {
+		return nil
+	}
+	var err error
+	if c.Device, err = OpenDevice(c.Info); err != nil {
+		return c.openFailed("event device", err)
+	}
+	if c.Info.JoystickPath != "" {
+		if c.Joystick, err = OpenJoystick(c.Info.JoystickPath); err != nil {
+			return c.openFailed("joystick", err)
+		}
+	}
+	if c.Info.LEDPath != "" || c.Info.LEDBrightnessPath != "" {
+		if c.LED, err = OpenLEDDevice(c.Info); err != nil {
+			return c.openFailed("LED", err)
+		}
+	}
+	if c.HidrawPath != "" {
+		if c.Hidraw, err = os.OpenFile(c.HidrawPath, os.O_RDWR, 0); err != nil {
+			return c.openFailed("hidraw", err)
+		}
+	}
+	if c.PowerSupplyPath != "" {
+		c.PowerSupply = &PowerSupply{Path: c.PowerSupplyPath}
+	}
+	return nil
+}
+
+// Close closes every open node of the controller.
+func (c *Controller) Close() error {
+	var errs []error
+	if c.Device != nil {
+		errs = append(errs, c.Device.Close())
+		c.Device = nil
+	}
+	if c.Joystick != nil {
+		errs = append(errs, c.Joystick.Close())
+		c.Joystick = nil
+	}
+	if c.Hidraw != nil {
+		errs = append(errs, c.Hidraw.Close())
+		c.Hidraw = nil
+	}
+	c.LED = nil
+	c.PowerSupply = nil
+	return errors.Join(errs...)
+}
+
+func (c *Controller) openFailed(node string, err error) error {
+	c.Close()
+	return fmt.Errorf("xpad: open controller %s: %w", node, err)
+}
+
+// PowerSupply is a sysfs power_supply device, typically a pad battery.
+type PowerSupply struct {
+	Path string
+}
+
+// Capacity returns the remaining charge in percent.
+func (p *PowerSupply) Capacity() (int, error) {
+	text, err := p.read("capacity")
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(text)
+}
+
+// Status returns the charging status, e.g. "Discharging" or "Full".
+func (p *PowerSupply) Status() (string, error) {
+	return p.read("status")
+}
+
+func (p *PowerSupply) read(name string) (string, error) {
+	if p == nil || p.Path == "" {
+		return "", ErrNotFound
+	}
+	data, err := os.ReadFile(filepath.Join(p.Path, name))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
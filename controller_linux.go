@@ -0,0 +1,91 @@
+//go:build linux
+
+package xpad
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ListControllers groups the nodes of every xpad-backed controller.
+func ListControllers() ([]Controller, error) {
+	return defaultDiscoverer.ListControllers()
+}
+
+// ListControllers groups the nodes of every xpad-backed controller by walking
+// each event device's sysfs parents up to its USB interface.
+func (d *Discoverer) ListControllers() ([]Controller, error) {
+	infos, err := d.FindXpadDevices()
+	if err != nil {
+		return nil, err
+	}
+	hidraws, err := resolveClassDevices(d.path("sys/class/hidraw/hidraw*"))
+	if err != nil {
+		return nil, err
+	}
+	supplies, err := resolveClassDevices(d.path("sys/class/power_supply/*"))
+	if err != nil {
+		return nil, err
+	}
+
+	controllers := make([]Controller, 0, len(infos))
+	for _, info := range infos {
+		c := Controller{Info: info}
+		c.InterfacePath = usbInterfacePath(info.DevicePath, d.path("sys"))
+		if c.InterfacePath != "" {
+			if node, ok := findUnder(hidraws, c.InterfacePath); ok {
+				c.HidrawPath = d.path("dev", filepath.Base(node))
+			}
+			if node, ok := findUnder(supplies, c.InterfacePath); ok {
+				c.PowerSupplyPath = node
+			}
+		}
+		controllers = append(controllers, c)
+	}
+	return controllers, nil
+}
+
+// usbInterfacePath walks up from path to the first USB interface directory.
+func usbInterfacePath(path, stop string) string {
+	for path != "" && path != stop && path != filepath.Dir(path) {
+		if _, err := os.Stat(filepath.Join(path, "bInterfaceNumber")); err == nil {
+			return path
+		}
+		path = filepath.Dir(path)
+	}
+	return ""
+}
+
+// resolveClassDevices maps each class node matching globPattern to its
+// resolved backing device.
+func resolveClassDevices(globPattern string) (map[string]string, error) {
+	paths, err := filepath.Glob(globPattern)
+	if err != nil {
+		return nil, err
+	}
+	resolved := make(map[string]string, len(paths))
+	for _, node := range paths {
+		target, err := filepath.EvalSymlinks(filepath.Join(node, "device"))
+		if err != nil {
+			continue
+		}
+		resolved[node] = target
+	}
+	return resolved, nil
+}
+
+// findUnder returns the first class node (in name order) whose device is dir
+// or lies below it.
+func findUnder(nodes map[string]string, dir string) (string, bool) {
+	var match string
+	for node, device := range nodes {
+		if device != dir && !strings.HasPrefix(device, dir+string(filepath.Separator)) {
+			continue
+		}
+		if match == "" || node < match {
+			match = node
+		}
+	}
+	return match, match != ""
+}
@@ -0,0 +1,82 @@
+//go:build linux
+
+package xpad
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiscovererListControllers(t *testing.T) {
+	root := loadSysfsFixture(t, "xboxone")
+	intf := filepath.Join(root, "sys/devices/pci0000:00/0000:00:14.0/usb1/1-4/1-4:1.0")
+
+	// xpad itself creates neither node; add a HID child and a battery on the
+	// same interface to exercise the grouping.
+	hid := filepath.Join(intf, "0003:045E:02EA.0001")
+	mustSymlink(t, "../../../0003:045E:02EA.0001", filepath.Join(hid, "hidraw/hidraw3/device"))
+	mustSymlink(t, "../../devices/pci0000:00/0000:00:14.0/usb1/1-4/1-4:1.0/0003:045E:02EA.0001/hidraw/hidraw3", filepath.Join(root, "sys/class/hidraw/hidraw3"))
+	mustWrite(t, filepath.Join(root, "dev/hidraw3"), "")
+	battery := filepath.Join(intf, "power_supply/xpad_battery_0")
+	mustSymlink(t, "../../../1-4:1.0", filepath.Join(battery, "device"))
+	mustWrite(t, filepath.Join(battery, "capacity"), "80\n")
+	mustWrite(t, filepath.Join(battery, "status"), "Discharging\n")
+	mustSymlink(t, "../../devices/pci0000:00/0000:00:14.0/usb1/1-4/1-4:1.0/power_supply/xpad_battery_0", filepath.Join(root, "sys/class/power_supply/xpad_battery_0"))
+
+	controllers, err := NewDiscoverer(root).ListControllers()
+	if err != nil {
+		t.Fatalf("ListControllers() error: %v", err)
+	}
+	if len(controllers) != 1 {
+		t.Fatalf("ListControllers() returned %d controllers, want 1", len(controllers))
+	}
+	c := controllers[0]
+	if c.InterfacePath != intf {
+		t.Fatalf("InterfacePath = %q, want %q", c.InterfacePath, intf)
+	}
+	if c.HidrawPath != filepath.Join(root, "dev/hidraw3") {
+		t.Fatalf("HidrawPath = %q", c.HidrawPath)
+	}
+	if c.PowerSupplyPath != filepath.Join(root, "sys/class/power_supply/xpad_battery_0") {
+		t.Fatalf("PowerSupplyPath = %q", c.PowerSupplyPath)
+	}
+
+	if err := c.Open(); err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if c.Device == nil || c.Joystick == nil || c.Hidraw == nil || c.LED != nil {
+		t.Fatalf("Open() handles device=%v joystick=%v hidraw=%v led=%v", c.Device, c.Joystick, c.Hidraw, c.LED)
+	}
+	if capacity, err := c.PowerSupply.Capacity(); err != nil || capacity != 80 {
+		t.Fatalf("Capacity() = %d, %v, want 80", capacity, err)
+	}
+	if status, err := c.PowerSupply.Status(); err != nil || status != "Discharging" {
+		t.Fatalf("Status() = %q, %v, want Discharging", status, err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+	if c.Device != nil || c.Joystick != nil || c.Hidraw != nil || c.PowerSupply != nil {
+		t.Fatalf("Close() left handles open")
+	}
+}
+
+func TestControllerOpenFailureClosesNodes(t *testing.T) {
+	root := loadSysfsFixture(t, "xbox360-wired")
+	controllers, err := NewDiscoverer(root).ListControllers()
+	if err != nil || len(controllers) != 1 {
+		t.Fatalf("ListControllers() = %d, %v", len(controllers), err)
+	}
+	c := controllers[0]
+	if c.Info.LEDPath == "" {
+		t.Fatalf("wired controller has no LED")
+	}
+	c.HidrawPath = filepath.Join(root, "dev/hidraw-missing")
+
+	if err := c.Open(); err == nil {
+		t.Fatalf("Open() error = nil, want missing hidraw error")
+	}
+	if c.Device != nil || c.Joystick != nil || c.LED != nil {
+		t.Fatalf("failed Open() left handles open")
+	}
+}
@@ -1,6 +1,9 @@
 package xpad
 
-import "strings"
+import (
+	"slices"
+	"strings"
+)
 
 // DeviceInfo describes a discovered input device.
 type DeviceInfo struct {
@@ -17,6 +20,13 @@ type DeviceInfo struct {
 	LEDPath string
 	// LEDBrightnessPath is the sysfs brightness file for the LED device if present.
 	LEDBrightnessPath string
+	// ByID and ByPath list the udev /dev/input/by-id and /dev/input/by-path
+	// symlinks pointing at the event or joystick node.
+	ByID   []string
+	ByPath []string
+	// Handlers lists the input handlers attached to the device (e.g. "event5",
+	// "js0"), as reported by /proc/bus/input/devices.
+	Handlers []string
 
 	Name      string
 	Phys      string
@@ -26,15 +36,51 @@ type DeviceInfo struct {
 	VendorID  uint16
 	ProductID uint16
 	VersionID uint16
+
+	// Modalias is the raw input modalias string; see ParseModalias.
+	Modalias string
+	// Capabilities is read from the world-readable sysfs capability bitmaps,
+	// so it is available without opening the event device.
+	Capabilities Capabilities
+	// USB describes the backing USB device; it is zero for non-USB devices.
+	USB USBInfo
+	// Udev and JoystickUdev are the udev database records for the event and
+	// joystick nodes; they are zero when udev has not recorded the node.
+	Udev         UdevData
+	JoystickUdev UdevData
+}
+
+// Seat returns the udev seat of the event node; see UdevData.Seat.
+func (d DeviceInfo) Seat() string {
+	return d.Udev.Seat()
+}
+
+// IsGamepad reports whether the advertised capabilities look like a gamepad.
+func (d DeviceInfo) IsGamepad() bool {
+	return d.Capabilities.IsGamepad()
 }
 
 // IsXpad reports whether the device appears to be handled by the xpad driver.
+// A known driver decides on its own, so pads bound to hid-generic or xpadneo
+// are not xpad devices even when their IDs are in the device table; the
+// table and the name are only consulted when the driver is unknown.
 func (d DeviceInfo) IsXpad() bool {
-	driver := strings.ToLower(d.Driver)
-	if strings.Contains(driver, "xpad") {
+	if d.Driver != "" {
+		return isXpadDriver(d.Driver)
+	}
+	if _, ok := d.KnownDevice(); ok {
 		return true
 	}
 
 	name := strings.ToLower(d.Name)
 	return strings.Contains(name, "xpad") || strings.Contains(name, "xbox") || strings.Contains(name, "x-box")
 }
+
+// isXpadDriver matches "xpad" as a whole word of the driver name, so that
+// e.g. "xpadneo" does not count.
+func isXpadDriver(driver string) bool {
+	words := strings.FieldsFunc(strings.ToLower(driver), func(r rune) bool {
+		return (r < 'a' || r > 'z') && (r < '0' || r > '9')
+	})
+	return slices.Contains(words, "xpad")
+}
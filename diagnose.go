@@ -0,0 +1,136 @@
+package xpad
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CheckStatus is the outcome of a diagnostic check.
+type CheckStatus uint8
+
+const (
+	CheckOK CheckStatus = iota
+	// CheckSkipped means the check could not run, e.g. because an earlier
+	// check failed.
+	CheckSkipped
+	CheckWarn
+	CheckFail
+)
+
+// String returns the status as shown in reports.
+func (s CheckStatus) String() string {
+	switch s {
+	case CheckOK:
+		return "ok"
+	case CheckSkipped:
+		return "skip"
+	case CheckWarn:
+		return "warn"
+	case CheckFail:
+		return "FAIL"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText encodes the status for JSON reports.
+func (s CheckStatus) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(s.String())), nil
+}
+
+// DiagnosticCheck is one entry of a DiagnosticReport.
+type DiagnosticCheck struct {
+	// Name identifies the check, e.g. "module" or "event-access".
+	Name string `json:"name"`
+	// Target is the path or device the check looked at.
+	Target string      `json:"target,omitempty"`
+	Status CheckStatus `json:"status"`
+	// Detail describes what was found.
+	Detail string `json:"detail"`
+	// Remedy suggests a fix when Status is CheckWarn or CheckFail.
+	Remedy string `json:"remedy,omitempty"`
+}
+
+// DiagnosticReport collects the results of Diagnose.
+type DiagnosticReport struct {
+	UID    int               `json:"uid"`
+	Groups []int             `json:"groups"`
+	Checks []DiagnosticCheck `json:"checks"`
+
+	// live is set when checking the real system rather than a fixture root.
+	live bool
+}
+
+func (r *DiagnosticReport) add(check DiagnosticCheck) {
+	r.Checks = append(r.Checks, check)
+}
+
+// OK reports whether no check failed. Warnings do not count as failures.
+func (r *DiagnosticReport) OK() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the checks with status CheckFail.
+func (r *DiagnosticReport) Failed() []DiagnosticCheck {
+	var failed []DiagnosticCheck
+	for _, check := range r.Checks {
+		if check.Status == CheckFail {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// String formats the report as one line per check, with remedies indented
+// below warnings and failures.
+func (r *DiagnosticReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "uid %d, groups %v\n", r.UID, r.Groups)
+	for _, check := range r.Checks {
+		fmt.Fprintf(&b, "[%-4s] %s", check.Status, check.Name)
+		if check.Target != "" {
+			fmt.Fprintf(&b, " %s", check.Target)
+		}
+		fmt.Fprintf(&b, ": %s\n", check.Detail)
+		if check.Remedy != "" && check.Status >= CheckWarn {
+			fmt.Fprintf(&b, "       -> %s\n", check.Remedy)
+		}
+	}
+	return b.String()
+}
+
+// accessMode selects the permission checked by canAccess.
+type accessMode uint8
+
+const (
+	accessRead  accessMode = 4
+	accessWrite accessMode = 2
+)
+
+// canAccess applies the owner/group/other permission bits of a file to a
+// user, like access(2) without ACLs. Root may read and write anything. It is
+// the fallback for fixture roots, where the kernel cannot be asked.
+func canAccess(mode uint32, fileUID, fileGID uint32, uid int, groups []int, want accessMode) bool {
+	if uid == 0 {
+		return true
+	}
+	var bits uint32
+	switch {
+	case uint32(uid) == fileUID:
+		bits = mode >> 6
+	case containsGroup(groups, fileGID):
+		bits = mode >> 3
+	default:
+		bits = mode
+	}
+	return bits&uint32(want) == uint32(want)
+}
+
+func containsGroup(groups []int, gid uint32) bool {
+	for _, group := range groups {
+		if uint32(group) == gid {
+			return true
+		}
+	}
+	return false
+}
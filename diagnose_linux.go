@@ -0,0 +1,266 @@
+//go:build linux
+
+package xpad
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"syscall"
+)
+
+const installRulesHint = "install udev rules: xpadctl udev-rules | sudo tee /etc/udev/rules.d/70-xpad.rules"
+
+// faccessat(2) arguments the syscall package does not export.
+const (
+	atFdcwd = -0x64
+	// atEAccess checks with the effective rather than the real IDs.
+	atEAccess = 0x200
+)
+
+// Diagnose checks the xpad module, the connected controllers and the current
+// user's access to them, and returns a report with remediation hints.
+func Diagnose() (*DiagnosticReport, error) {
+	return defaultDiscoverer.Diagnose()
+}
+
+// Diagnose runs the diagnostics against the root. Problems found, including
+// a failure to enumerate input devices, are reported as checks.
+func (d *Discoverer) Diagnose() (*DiagnosticReport, error) {
+	report := &DiagnosticReport{UID: os.Geteuid(), live: d.Root == "" || d.Root == "/"}
+	// getgroups(2) may leave out the primary group.
+	report.Groups = []int{os.Getegid()}
+	if groups, err := os.Getgroups(); err == nil {
+		for _, gid := range groups {
+			if !slices.Contains(report.Groups, gid) {
+				report.Groups = append(report.Groups, gid)
+			}
+		}
+	}
+
+	d.diagnoseModule(report)
+
+	infos, err := d.FindXpadDevices()
+	if err != nil {
+		report.add(DiagnosticCheck{
+			Name:   "devices",
+			Target: d.path("sys/class/input"),
+			Status: CheckFail,
+			Detail: err.Error(),
+			Remedy: "mount sysfs on /sys or procfs on /proc, e.g. pass them into the container",
+		})
+		return report, nil
+	}
+	if len(infos) == 0 {
+		report.add(DiagnosticCheck{
+			Name:   "devices",
+			Status: CheckFail,
+			Detail: "no xpad controllers found",
+			Remedy: "connect a controller and check `dmesg | grep xpad`",
+		})
+		return report, nil
+	}
+	for _, info := range infos {
+		d.diagnoseDevice(report, info)
+	}
+	return report, nil
+}
+
+func (d *Discoverer) diagnoseModule(report *DiagnosticReport) {
+	moduleDir := d.path("sys/module/xpad")
+	module, err := d.GetModuleInfo()
+	if err != nil || !module.Loaded {
+		check := DiagnosticCheck{
+			Name:   "module",
+			Target: moduleDir,
+			Status: CheckFail,
+			Detail: "xpad module is not loaded",
+			Remedy: "sudo modprobe xpad",
+		}
+		if err != nil {
+			check.Detail = err.Error()
+		}
+		report.add(check)
+		return
+	}
+
+	state := module.State
+	if module.BuiltIn {
+		state = "built in"
+	}
+	detail := "loaded (" + state + ")"
+	if module.Version != "" {
+		detail += ", version " + module.Version
+	}
+	if module.SrcVersion != "" {
+		detail += ", srcversion " + module.SrcVersion
+	}
+	detail += fmt.Sprintf(", %d bound interfaces", len(module.BoundInterfaces))
+	report.add(DiagnosticCheck{Name: "module", Target: moduleDir, Status: CheckOK, Detail: detail})
+
+	paramDir := d.path(moduleParamDir)
+	entries, err := os.ReadDir(paramDir)
+	if err != nil {
+		report.add(DiagnosticCheck{
+			Name:   "module-params",
+			Target: paramDir,
+			Status: CheckWarn,
+			Detail: err.Error(),
+		})
+		return
+	}
+	var values []string
+	writable := 0
+	for _, entry := range entries {
+		path := filepath.Join(paramDir, entry.Name())
+		values = append(values, entry.Name()+"="+readTrimmedFile(path))
+		if ok, _ := report.canAccessPath(path, accessWrite); ok {
+			writable++
+		}
+	}
+	sort.Strings(values)
+	check := DiagnosticCheck{
+		Name:   "module-params",
+		Target: paramDir,
+		Status: CheckOK,
+		Detail: strings.Join(values, " "),
+	}
+	if writable == 0 && len(entries) > 0 {
+		check.Status = CheckWarn
+		check.Detail += " (read-only for this user)"
+		check.Remedy = "run as root or install xpadctl udev-rules -tmpfiles as /etc/tmpfiles.d/xpad.conf"
+	}
+	report.add(check)
+}
+
+func (d *Discoverer) diagnoseDevice(report *DiagnosticReport, info DeviceInfo) {
+	readable := report.checkNode("event-access", info.Path, info.Udev)
+	if info.JoystickPath != "" {
+		report.checkNode("js-access", info.JoystickPath, info.JoystickUdev)
+	}
+
+	udev := DiagnosticCheck{Name: "udev", Target: info.Path, Status: CheckOK}
+	if info.Udev.Properties == nil {
+		udev.Status = CheckWarn
+		udev.Detail = "no udev database record"
+		udev.Remedy = "check that systemd-udevd is running, then `sudo udevadm trigger`"
+	} else {
+		tags := append(append([]string(nil), info.Udev.Tags...), info.Udev.CurrentTags...)
+		sort.Strings(tags)
+		tags = slices.Compact(tags)
+		udev.Detail = fmt.Sprintf("tags %s", strings.Join(tags, ","))
+		if seat := info.Seat(); seat != "" {
+			udev.Detail += ", seat " + seat
+		}
+	}
+	report.add(udev)
+
+	grab := DiagnosticCheck{Name: "grab", Target: info.Path}
+	if !readable {
+		grab.Status = CheckSkipped
+		grab.Detail = "event node not readable"
+	} else {
+		grab.Status, grab.Detail = probeGrab(info.Path)
+		if grab.Status == CheckFail {
+			grab.Remedy = fmt.Sprintf("close the program holding it (e.g. Steam or a remapper); see `fuser -v %s`", info.Path)
+		}
+	}
+	report.add(grab)
+
+	led := DiagnosticCheck{Name: "led", Target: info.LEDBrightnessPath}
+	switch ok, err := report.canAccessPath(info.LEDBrightnessPath, accessWrite); {
+	case info.LEDBrightnessPath == "":
+		led.Status = CheckSkipped
+		led.Detail = "no LED"
+	case err != nil:
+		led.Status = CheckWarn
+		led.Detail = err.Error()
+	case !ok:
+		led.Status = CheckWarn
+		led.Detail = "brightness is not writable"
+		led.Remedy = installRulesHint
+	default:
+		led.Status = CheckOK
+		led.Detail = "writable"
+	}
+	report.add(led)
+}
+
+// checkNode records read/write access to a device node and reports whether it
+// is readable.
+func (r *DiagnosticReport) checkNode(name, path string, udev UdevData) bool {
+	check := DiagnosticCheck{Name: name, Target: path}
+	readable, err := r.canAccessPath(path, accessRead)
+	writable, _ := r.canAccessPath(path, accessWrite)
+	switch {
+	case err != nil:
+		check.Status = CheckFail
+		check.Detail = err.Error()
+	case !readable:
+		check.Status = CheckFail
+		check.Detail = "not readable"
+		check.Remedy = installRulesHint
+		if udev.HasTag("uaccess") {
+			check.Remedy = "the node is tagged uaccess; log in on the active local seat, or use -group rules: xpadctl udev-rules -group input"
+		}
+	case !writable:
+		check.Status = CheckWarn
+		check.Detail = "read-only: rumble and LED commands are unavailable"
+		check.Remedy = installRulesHint
+	default:
+		check.Status = CheckOK
+		check.Detail = "read-write"
+	}
+	r.add(check)
+	return readable
+}
+
+// canAccessPath asks the kernel on the live system, so ACLs such as those
+// logind sets for TAG+="uaccess" count. Below a fixture root the owners are
+// not real accounts, so only the mode bits are checked.
+func (r *DiagnosticReport) canAccessPath(path string, want accessMode) (bool, error) {
+	if path == "" {
+		return false, nil
+	}
+	fi, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	if r.live {
+		switch err := syscall.Faccessat(atFdcwd, path, uint32(want), atEAccess); {
+		case err == nil:
+			return true, nil
+		case errors.Is(err, syscall.EACCES), errors.Is(err, syscall.EPERM), errors.Is(err, syscall.EROFS):
+			return false, nil
+		default:
+			return false, fmt.Errorf("xpad: access %s: %w", path, err)
+		}
+	}
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok {
+		return false, fmt.Errorf("xpad: stat %s: no ownership information", path)
+	}
+	return canAccess(uint32(fi.Mode().Perm()), st.Uid, st.Gid, r.UID, r.Groups, want), nil
+}
+
+// probeGrab briefly grabs the event node to see whether another process
+// holds it exclusively.
+func probeGrab(path string) (CheckStatus, string) {
+	dev, err := OpenWithOptions(path, Options{Access: AccessReadOnly, NonBlocking: true})
+	if err != nil {
+		return CheckSkipped, err.Error()
+	}
+	defer dev.Close()
+	if err := dev.Grab(true); err != nil {
+		if errors.Is(err, syscall.EBUSY) {
+			return CheckFail, "grabbed by another process"
+		}
+		return CheckSkipped, fmt.Sprintf("grab probe failed: %v", err)
+	}
+	_ = dev.Grab(false)
+	return CheckOK, "not grabbed"
+}
@@ -0,0 +1,95 @@
+//go:build linux
+
+package xpad
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiscovererDiagnose(t *testing.T) {
+	root := loadSysfsFixture(t, "xbox360-wired")
+	report, err := NewDiscoverer(root).Diagnose()
+	if err != nil {
+		t.Fatalf("Diagnose() error: %v", err)
+	}
+	if !report.OK() {
+		t.Fatalf("Diagnose() failed checks:\n%s", report)
+	}
+	statuses := make(map[string]CheckStatus)
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	want := map[string]CheckStatus{
+		"module": CheckOK,
+		"udev":   CheckOK,
+		// The fixture node is a regular file, so the grab probe cannot run.
+		"grab": CheckSkipped,
+	}
+	for name, status := range want {
+		if statuses[name] != status {
+			t.Fatalf("%s status = %v, want %v\n%s", name, statuses[name], status, report)
+		}
+	}
+
+	if err := os.RemoveAll(filepath.Join(root, "sys/module/xpad")); err != nil {
+		t.Fatalf("remove module: %v", err)
+	}
+	report, err = NewDiscoverer(root).Diagnose()
+	if err != nil {
+		t.Fatalf("Diagnose() error: %v", err)
+	}
+	if failed := report.Failed(); len(failed) == 0 || failed[0].Name != "module" || failed[0].Remedy == "" {
+		t.Fatalf("Failed() = %+v, want module failure with remedy", failed)
+	}
+}
+
+func TestCanAccessPathLive(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "node")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	// The owner bits of a file we own grant read and write, whatever the
+	// report claims about groups.
+	r := &DiagnosticReport{UID: os.Geteuid(), live: true}
+	for _, want := range []accessMode{accessRead, accessWrite} {
+		if ok, err := r.canAccessPath(path, want); !ok || err != nil {
+			t.Fatalf("canAccessPath(%s, %d) = %v, %v, want true", path, want, ok, err)
+		}
+	}
+	if _, err := r.canAccessPath(filepath.Join(dir, "missing"), accessRead); err == nil {
+		t.Fatalf("canAccessPath(missing) error = nil")
+	}
+}
+
+func TestDiagnoseGroupsIncludePrimary(t *testing.T) {
+	root := loadSysfsFixture(t, "xbox360-wired")
+	report, err := NewDiscoverer(root).Diagnose()
+	if err != nil {
+		t.Fatalf("Diagnose() error: %v", err)
+	}
+	if !containsGroup(report.Groups, uint32(os.Getegid())) {
+		t.Fatalf("Groups = %v, want primary gid %d", report.Groups, os.Getegid())
+	}
+}
+
+func TestDiagnoseWithoutInputDevices(t *testing.T) {
+	report, err := NewDiscoverer(t.TempDir()).Diagnose()
+	if err != nil {
+		t.Fatalf("Diagnose() error: %v", err)
+	}
+	var names []string
+	for _, check := range report.Failed() {
+		names = append(names, check.Name)
+	}
+	if !slices.Equal(names, []string{"module", "devices"}) {
+		t.Fatalf("failed checks = %q, want module and devices:\n%s", names, report)
+	}
+	if devices := report.Failed()[1]; devices.Remedy == "" || !strings.Contains(devices.Detail, "no such file") {
+		t.Fatalf("devices check = %+v", devices)
+	}
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package xpad
+
+// Diagnose is not supported on non-Linux platforms.
+func Diagnose() (*DiagnosticReport, error) {
+	return nil, ErrNotImplemented
+}
+
+// Diagnose is not supported on non-Linux platforms.
+func (d *Discoverer) Diagnose() (*DiagnosticReport, error) {
+	return nil, ErrNotImplemented
+}
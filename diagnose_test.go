@@ -0,0 +1,48 @@
+package xpad
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanAccess(t *testing.T) {
+	cases := []struct {
+		name   string
+		mode   uint32
+		uid    int
+		groups []int
+		want   accessMode
+		ok     bool
+	}{
+		{"root", 0o000, 0, nil, accessWrite, true},
+		{"owner read", 0o600, 1000, nil, accessRead, true},
+		{"owner no write", 0o400, 1000, nil, accessWrite, false},
+		{"group read", 0o640, 1001, []int{104}, accessRead, true},
+		{"group no write", 0o640, 1001, []int{104}, accessWrite, false},
+		{"other denied", 0o660, 1001, []int{100}, accessRead, false},
+		{"other read", 0o664, 1001, nil, accessRead, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := canAccess(tc.mode, 1000, 104, tc.uid, tc.groups, tc.want); got != tc.ok {
+				t.Fatalf("canAccess(%o) = %v, want %v", tc.mode, got, tc.ok)
+			}
+		})
+	}
+}
+
+func TestDiagnosticReport(t *testing.T) {
+	report := &DiagnosticReport{UID: 1000, Groups: []int{1000}}
+	report.add(DiagnosticCheck{Name: "module", Status: CheckOK, Detail: "loaded (live)"})
+	report.add(DiagnosticCheck{Name: "event-access", Target: "/dev/input/event5", Status: CheckFail, Detail: "not readable", Remedy: "install udev rules"})
+	if report.OK() {
+		t.Fatalf("OK() = true, want false")
+	}
+	if failed := report.Failed(); len(failed) != 1 || failed[0].Name != "event-access" {
+		t.Fatalf("Failed() = %+v, want event-access", failed)
+	}
+	want := "[FAIL] event-access /dev/input/event5: not readable\n       -> install udev rules\n"
+	if got := report.String(); !strings.HasSuffix(got, want) {
+		t.Fatalf("String() = %q, want suffix %q", got, want)
+	}
+}
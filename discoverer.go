@@ -0,0 +1,29 @@
+package xpad
+
+import "path/filepath"
+
+// Discoverer locates devices, LEDs and module parameters below a root
+// directory. The zero value scans the live system; a non-empty Root lets
+// discovery run against remapped container mounts or fixture trees.
+type Discoverer struct {
+	// Root is prepended to every /dev and /sys path. Empty means "/".
+	Root string
+}
+
+// NewDiscoverer returns a Discoverer rooted at root.
+func NewDiscoverer(root string) *Discoverer {
+	return &Discoverer{Root: root}
+}
+
+var defaultDiscoverer = &Discoverer{}
+
+// moduleParamDir holds the xpad module parameter files, relative to the root.
+const moduleParamDir = "sys/module/xpad/parameters"
+
+func (d *Discoverer) path(elem ...string) string {
+	root := "/"
+	if d != nil && d.Root != "" {
+		root = d.Root
+	}
+	return filepath.Join(append([]string{root}, elem...)...)
+}
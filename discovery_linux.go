@@ -3,25 +3,58 @@
 package xpad
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 )
 
 // ListDevices scans /dev/input for event devices and enriches them via sysfs.
 func ListDevices() ([]DeviceInfo, error) {
-	jsMap, err := mapSysfsDevices("/sys/class/input/js*", "/dev/input")
+	return defaultDiscoverer.ListDevices()
+}
+
+// FindXpadDevices returns only devices that look like xpad-backed controllers.
+func FindXpadDevices() ([]DeviceInfo, error) {
+	return defaultDiscoverer.FindXpadDevices()
+}
+
+// OpenFirstXpad opens the first xpad-backed device discovered.
+func OpenFirstXpad() (*Device, error) {
+	return defaultDiscoverer.OpenFirstXpad()
+}
+
+// ListDevices scans <root>/dev/input for event devices and enriches them via
+// <root>/sys. Without <root>/sys/class/input (e.g. in containers that only
+// mount /proc) it falls back to <root>/proc/bus/input/devices, and returns
+// the error reading it if that fails too.
+func (d *Discoverer) ListDevices() ([]DeviceInfo, error) {
+	if _, err := os.Stat(d.path("sys/class/input")); err != nil {
+		return d.listProcDevices()
+	}
+
+	sysRoot := d.path("sys")
+	jsMap, err := mapSysfsDevices(d.path("sys/class/input/js*"), d.path("dev/input"))
 	if err != nil {
 		return nil, err
 	}
-	ledMap, err := mapSysfsDevices("/sys/class/leds/xpad*", "")
+	ledMap, err := mapSysfsDevices(d.path("sys/class/leds/xpad*"), "")
 	if err != nil {
 		return nil, err
 	}
 
-	paths, err := filepath.Glob("/dev/input/event*")
+	paths, err := filepath.Glob(d.path("dev/input/event*"))
+	if err != nil {
+		return nil, err
+	}
+	byID, err := resolveDevLinks(d.path("dev/input/by-id/*"))
+	if err != nil {
+		return nil, err
+	}
+	byPath, err := resolveDevLinks(d.path("dev/input/by-path/*"))
 	if err != nil {
 		return nil, err
 	}
@@ -29,25 +62,34 @@ func ListDevices() ([]DeviceInfo, error) {
 	infos := make([]DeviceInfo, 0, len(paths))
 	for _, path := range paths {
 		base := filepath.Base(path)
-		sysfs := filepath.Join("/sys/class/input", base)
+		sysfs := d.path("sys/class/input", base)
 
 		info := DeviceInfo{Path: path, SysfsPath: sysfs}
 		devPath := filepath.Join(sysfs, "device")
 		if resolved, err := filepath.EvalSymlinks(devPath); err == nil {
 			info.DevicePath = resolved
+			info.USB = readUSBInfo(resolved, sysRoot)
 			if jsPath, ok := jsMap[resolved]; ok {
 				info.JoystickPath = jsPath
 			}
-			if ledPath, ok := ledMap[resolved]; ok {
+			// xpad registers its LED on the USB device, above the input device.
+			if ledPath, ok := lookupAncestor(ledMap, resolved, sysRoot); ok {
 				info.LEDPath = ledPath
 				info.LEDBrightnessPath = filepath.Join(ledPath, "brightness")
 			}
 		}
 
+		info.Handlers = readHandlers(devPath)
 		info.Name = readTrimmedFile(filepath.Join(devPath, "name"))
 		info.Phys = readTrimmedFile(filepath.Join(devPath, "phys"))
 		info.Uniq = readTrimmedFile(filepath.Join(devPath, "uniq"))
+		// Input devices have no driver link; xpad binds the parent USB interface.
 		info.Driver = readLinkBase(filepath.Join(devPath, "driver"))
+		if info.Driver == "" {
+			info.Driver = readLinkBase(filepath.Join(devPath, "device", "driver"))
+		}
+		info.Modalias = readTrimmedFile(filepath.Join(devPath, "modalias"))
+		info.Capabilities = readCapabilities(devPath, info.Modalias)
 
 		if v, ok := readHexUint16(filepath.Join(devPath, "id", "bustype")); ok {
 			info.BusType = v
@@ -62,6 +104,10 @@ func ListDevices() ([]DeviceInfo, error) {
 			info.VersionID = v
 		}
 
+		info.ByID = linksTo(byID, info.Path, info.JoystickPath)
+		info.ByPath = linksTo(byPath, info.Path, info.JoystickPath)
+		d.attachUdevData(&info)
+
 		infos = append(infos, info)
 	}
 
@@ -72,9 +118,46 @@ func ListDevices() ([]DeviceInfo, error) {
 	return infos, nil
 }
 
+// listProcDevices lists event devices from <root>/proc/bus/input/devices.
+func (d *Discoverer) listProcDevices() ([]DeviceInfo, error) {
+	file, err := os.Open(d.path("proc/bus/input/devices"))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	parsed, err := d.parseProcInputDevices(file)
+	if err != nil {
+		return nil, err
+	}
+	byID, err := resolveDevLinks(d.path("dev/input/by-id/*"))
+	if err != nil {
+		return nil, err
+	}
+	byPath, err := resolveDevLinks(d.path("dev/input/by-path/*"))
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]DeviceInfo, 0, len(parsed))
+	for _, info := range parsed {
+		if info.Path == "" {
+			continue
+		}
+		info.ByID = linksTo(byID, info.Path, info.JoystickPath)
+		info.ByPath = linksTo(byPath, info.Path, info.JoystickPath)
+		d.attachUdevData(&info)
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Path < infos[j].Path
+	})
+	return infos, nil
+}
+
 // FindXpadDevices returns only devices that look like xpad-backed controllers.
-func FindXpadDevices() ([]DeviceInfo, error) {
-	infos, err := ListDevices()
+func (d *Discoverer) FindXpadDevices() ([]DeviceInfo, error) {
+	infos, err := d.ListDevices()
 	if err != nil {
 		return nil, err
 	}
@@ -87,9 +170,77 @@ func FindXpadDevices() ([]DeviceInfo, error) {
 	return filtered, nil
 }
 
+// QueryDevices returns the devices matching q; see Query.Filter. A nil q
+// matches every device.
+func QueryDevices(q *Query) ([]DeviceInfo, error) {
+	return defaultDiscoverer.QueryDevices(q)
+}
+
+// OpenFirst opens the first device matching q. A nil q matches every device.
+func OpenFirst(q *Query) (*Device, error) {
+	return defaultDiscoverer.OpenFirst(q)
+}
+
+// QueryDevices returns the devices matching q; see Query.Filter.
+func (d *Discoverer) QueryDevices(q *Query) ([]DeviceInfo, error) {
+	infos, err := d.ListDevices()
+	if err != nil {
+		return nil, err
+	}
+	return q.Filter(infos)
+}
+
+// OpenFirst opens the first device matching q.
+func (d *Discoverer) OpenFirst(q *Query) (*Device, error) {
+	infos, err := d.QueryDevices(q)
+	if err != nil {
+		return nil, err
+	}
+	return OpenDevice(infos[0])
+}
+
+// OpenByStableID opens the device whose DeviceInfo.StableID matches id.
+func OpenByStableID(id string) (*Device, error) {
+	return defaultDiscoverer.OpenByStableID(id)
+}
+
+// FindByStableID returns the device whose DeviceInfo.StableID matches id. If
+// several devices share the ID, e.g. identical pads without serial numbers
+// whose port path changed, the error wraps ErrAmbiguous.
+func (d *Discoverer) FindByStableID(id string) (DeviceInfo, error) {
+	infos, err := d.ListDevices()
+	if err != nil {
+		return DeviceInfo{}, err
+	}
+	var match DeviceInfo
+	found := false
+	for _, info := range infos {
+		if id == "" || info.StableID() != id {
+			continue
+		}
+		if found {
+			return DeviceInfo{}, fmt.Errorf("xpad: stable ID %q matches %s and %s: %w", id, match.Path, info.Path, ErrAmbiguous)
+		}
+		match, found = info, true
+	}
+	if !found {
+		return DeviceInfo{}, ErrNotFound
+	}
+	return match, nil
+}
+
+// OpenByStableID opens the device whose DeviceInfo.StableID matches id.
+func (d *Discoverer) OpenByStableID(id string) (*Device, error) {
+	info, err := d.FindByStableID(id)
+	if err != nil {
+		return nil, err
+	}
+	return OpenDevice(info)
+}
+
 // OpenFirstXpad opens the first xpad-backed device discovered.
-func OpenFirstXpad() (*Device, error) {
-	infos, err := FindXpadDevices()
+func (d *Discoverer) OpenFirstXpad() (*Device, error) {
+	infos, err := d.FindXpadDevices()
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +250,130 @@ func OpenFirstXpad() (*Device, error) {
 	return Open(infos[0].Path)
 }
 
+// readUSBInfo walks up from devicePath to the USB interface and device and
+// reads their descriptor attributes.
+func readUSBInfo(devicePath, stop string) USBInfo {
+	usb := USBInfo{InterfaceNumber: -1}
+	usbDev := ""
+	if intf := usbInterfacePath(devicePath, stop); intf != "" {
+		if v, ok := readHexUint16(filepath.Join(intf, "bInterfaceNumber")); ok {
+			usb.InterfaceNumber = int(v)
+		}
+		usbDev = filepath.Dir(intf)
+	} else {
+		for path := devicePath; path != stop && path != filepath.Dir(path); path = filepath.Dir(path) {
+			if _, err := os.Stat(filepath.Join(path, "idVendor")); err == nil {
+				usbDev = path
+				break
+			}
+		}
+	}
+	if usbDev == "" {
+		return USBInfo{}
+	}
+
+	usb.SysfsPath = usbDev
+	usb.PortPath = filepath.Base(usbDev)
+	usb.BusNum, _ = strconv.Atoi(readTrimmedFile(filepath.Join(usbDev, "busnum")))
+	usb.DevNum, _ = strconv.Atoi(readTrimmedFile(filepath.Join(usbDev, "devnum")))
+	usb.Speed = readTrimmedFile(filepath.Join(usbDev, "speed"))
+	usb.Manufacturer = readTrimmedFile(filepath.Join(usbDev, "manufacturer"))
+	usb.Product = readTrimmedFile(filepath.Join(usbDev, "product"))
+	usb.Serial = readTrimmedFile(filepath.Join(usbDev, "serial"))
+	usb.BCDDevice, _ = readHexUint16(filepath.Join(usbDev, "bcdDevice"))
+	return usb
+}
+
+// readCapabilities parses the world-readable capabilities/* and properties
+// bitmaps under devPath, falling back to the modalias when they are missing.
+func readCapabilities(devPath, modalias string) Capabilities {
+	var caps Capabilities
+	if bits, err := parseSysfsBitmap(readTrimmedFile(filepath.Join(devPath, "capabilities", "ev"))); err == nil && len(bits) > 0 {
+		caps.Types = bits
+	} else if alias, err := ParseModalias(modalias); err == nil {
+		return alias.Capabilities
+	} else {
+		return caps
+	}
+	for _, file := range capabilityFiles {
+		bits, err := parseSysfsBitmap(readTrimmedFile(filepath.Join(devPath, "capabilities", file.name)))
+		if err != nil || len(bitsetCodes(bits)) == 0 {
+			continue
+		}
+		if caps.Codes == nil {
+			caps.Codes = make(map[EventKind][]byte)
+		}
+		caps.Codes[file.kind] = bits
+	}
+	if bits, err := parseSysfsBitmap(readTrimmedFile(filepath.Join(devPath, "properties"))); err == nil {
+		caps.Properties = bits
+	}
+	return caps
+}
+
+// attachUdevData reads the udev database records of the event and joystick
+// nodes from <root>/run/udev/data.
+func (d *Discoverer) attachUdevData(info *DeviceInfo) {
+	info.Udev = d.readUdevData(info.Path)
+	if info.JoystickPath != "" {
+		info.JoystickUdev = d.readUdevData(info.JoystickPath)
+	}
+}
+
+func (d *Discoverer) readUdevData(node string) UdevData {
+	number := readDevNumber(d.path("sys/class/input", filepath.Base(node), "dev"), node)
+	if number == "" {
+		return UdevData{}
+	}
+	file, err := os.Open(d.path("run/udev/data", "c"+number))
+	if err != nil {
+		return UdevData{}
+	}
+	defer file.Close()
+	data, err := ParseUdevData(file)
+	if err != nil {
+		return UdevData{}
+	}
+	return data
+}
+
+// readDevNumber returns "major:minor" for a character device, from its sysfs
+// dev file or, without sysfs, from the node itself.
+func readDevNumber(sysfsDev, node string) string {
+	if number := readTrimmedFile(sysfsDev); number != "" {
+		return number
+	}
+	var st syscall.Stat_t
+	if err := syscall.Stat(node, &st); err != nil || st.Mode&syscall.S_IFMT != syscall.S_IFCHR {
+		return ""
+	}
+	rdev := uint64(st.Rdev)
+	major := (rdev>>8)&0xfff | (rdev>>32)&^0xfff
+	minor := rdev&0xff | (rdev>>12)&^0xff
+	return fmt.Sprintf("%d:%d", major, minor)
+}
+
+// readHandlers lists the handler nodes (eventN, jsN, mouseN) under an input
+// device, matching the H: line of /proc/bus/input/devices.
+func readHandlers(devPath string) []string {
+	entries, err := os.ReadDir(devPath)
+	if err != nil {
+		return nil
+	}
+	var handlers []string
+	for _, entry := range entries {
+		name := entry.Name()
+		for _, prefix := range []string{"event", "js", "mouse"} {
+			if number, ok := strings.CutPrefix(name, prefix); ok && number != "" {
+				if _, err := strconv.Atoi(number); err == nil {
+					handlers = append(handlers, name)
+				}
+			}
+		}
+	}
+	return handlers
+}
+
 func readTrimmedFile(path string) string {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -134,6 +409,44 @@ func readLinkBase(path string) string {
 	return filepath.Base(link)
 }
 
+// resolveDevLinks maps each symlink matching globPattern to its target.
+func resolveDevLinks(globPattern string) (map[string]string, error) {
+	links, err := filepath.Glob(globPattern)
+	if err != nil {
+		return nil, err
+	}
+	resolved := make(map[string]string, len(links))
+	for _, link := range links {
+		if target, err := filepath.EvalSymlinks(link); err == nil {
+			resolved[link] = target
+		}
+	}
+	return resolved, nil
+}
+
+// linksTo returns the sorted links whose target is one of nodes.
+func linksTo(links map[string]string, nodes ...string) []string {
+	var matched []string
+	for _, node := range nodes {
+		if node == "" {
+			continue
+		}
+		target, err := filepath.EvalSymlinks(node)
+		if err != nil {
+			continue
+		}
+		for link, resolved := range links {
+			if resolved == target {
+				matched = append(matched, link)
+			}
+		}
+	}
+	sort.Strings(matched)
+	return matched
+}
+
+// mapSysfsDevices maps each resolved backing device to its class node. A
+// device shared by several nodes maps to "" so callers do not guess.
 func mapSysfsDevices(globPattern, devPrefix string) (map[string]string, error) {
 	paths, err := filepath.Glob(globPattern)
 	if err != nil {
@@ -146,6 +459,10 @@ func mapSysfsDevices(globPattern, devPrefix string) (map[string]string, error) {
 		if err != nil {
 			continue
 		}
+		if _, exists := mapping[resolved]; exists {
+			mapping[resolved] = ""
+			continue
+		}
 		base := filepath.Base(sysfs)
 		if devPrefix != "" {
 			mapping[resolved] = filepath.Join(devPrefix, base)
@@ -155,3 +472,15 @@ func mapSysfsDevices(globPattern, devPrefix string) (map[string]string, error) {
 	}
 	return mapping, nil
 }
+
+// lookupAncestor returns the mapping for path or its nearest mapped parent,
+// stopping at stop.
+func lookupAncestor(mapping map[string]string, path, stop string) (string, bool) {
+	for path != stop && path != filepath.Dir(path) {
+		if value, ok := mapping[path]; ok {
+			return value, value != ""
+		}
+		path = filepath.Dir(path)
+	}
+	return "", false
+}
@@ -0,0 +1,358 @@
+//go:build linux
+
+package xpad
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiscovererFixtures(t *testing.T) {
+	cases := []struct {
+		fixture   string
+		path      string
+		name      string
+		product   uint16
+		joystick  string
+		led       string
+		hasHat    bool
+		listCount int
+	}{
+		{
+			fixture:   "xbox360-wired",
+			path:      "dev/input/event5",
+			name:      "Microsoft X-Box 360 pad",
+			product:   0x028e,
+			joystick:  "dev/input/js0",
+			led:       "sys/class/leds/xpad0",
+			hasHat:    true,
+			listCount: 2,
+		},
+		{
+			fixture:   "xbox360-wireless",
+			path:      "dev/input/event7",
+			name:      "Xbox 360 Wireless Receiver",
+			product:   0x0719,
+			joystick:  "dev/input/js1",
+			led:       "sys/class/leds/xpad1",
+			listCount: 1,
+		},
+		{
+			fixture:   "xboxone",
+			path:      "dev/input/event9",
+			name:      "Microsoft X-Box One S pad",
+			product:   0x02ea,
+			joystick:  "dev/input/js2",
+			hasHat:    true,
+			listCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			root := loadSysfsFixture(t, tc.fixture)
+			d := NewDiscoverer(root)
+
+			all, err := d.ListDevices()
+			if err != nil {
+				t.Fatalf("ListDevices() error: %v", err)
+			}
+			if len(all) != tc.listCount {
+				t.Fatalf("ListDevices() returned %d devices, want %d", len(all), tc.listCount)
+			}
+
+			infos, err := d.FindXpadDevices()
+			if err != nil {
+				t.Fatalf("FindXpadDevices() error: %v", err)
+			}
+			if len(infos) != 1 {
+				t.Fatalf("FindXpadDevices() returned %d devices, want 1", len(infos))
+			}
+			info := infos[0]
+
+			if info.Path != filepath.Join(root, tc.path) {
+				t.Fatalf("Path = %q, want %q", info.Path, filepath.Join(root, tc.path))
+			}
+			if info.Name != tc.name || info.VendorID != 0x045e || info.ProductID != tc.product || info.BusType != 0x3 {
+				t.Fatalf("unexpected identity %q %04x:%04x bus %d", info.Name, info.VendorID, info.ProductID, info.BusType)
+			}
+			if info.Driver != "xpad" {
+				t.Fatalf("Driver = %q, want xpad", info.Driver)
+			}
+			if info.JoystickPath != filepath.Join(root, tc.joystick) {
+				t.Fatalf("JoystickPath = %q, want %q", info.JoystickPath, filepath.Join(root, tc.joystick))
+			}
+			wantLED := ""
+			if tc.led != "" {
+				wantLED = filepath.Join(root, tc.led)
+			}
+			if info.LEDPath != wantLED {
+				t.Fatalf("LEDPath = %q, want %q", info.LEDPath, wantLED)
+			}
+			if !info.IsGamepad() {
+				t.Fatalf("IsGamepad() = false, want true")
+			}
+			if got := info.Capabilities.HasEventCode(EVAbs, ABSHat0X); got != tc.hasHat {
+				t.Fatalf("HasEventCode(EVAbs, ABSHat0X) = %v, want %v", got, tc.hasHat)
+			}
+
+			params, err := d.GetModuleParams()
+			if err != nil {
+				t.Fatalf("GetModuleParams() error: %v", err)
+			}
+			if !params.Equal(ModuleParams{AutoPowerOff: true}) || params.Extra != nil {
+				t.Fatalf("GetModuleParams() = %+v", params)
+			}
+		})
+	}
+}
+
+func TestDiscovererOpenLEDByName(t *testing.T) {
+	d := NewDiscoverer(loadSysfsFixture(t, "xbox360-wired"))
+
+	led, err := d.OpenLED("xpad0")
+	if err != nil {
+		t.Fatalf("OpenLED() error: %v", err)
+	}
+	value, err := led.Brightness()
+	if err != nil {
+		t.Fatalf("Brightness() error: %v", err)
+	}
+	if value != int(LEDPlayer1) {
+		t.Fatalf("Brightness() = %d, want %d", value, LEDPlayer1)
+	}
+	if err := led.SetCommand(LEDPlayer2); err != nil {
+		t.Fatalf("SetCommand() error: %v", err)
+	}
+	if value, _ := led.Brightness(); value != int(LEDPlayer2) {
+		t.Fatalf("Brightness() after SetCommand = %d, want %d", value, LEDPlayer2)
+	}
+}
+
+func TestDiscovererUSBInfo(t *testing.T) {
+	cases := []struct {
+		fixture string
+		want    USBInfo
+	}{
+		{
+			fixture: "xbox360-wired",
+			want: USBInfo{
+				SysfsPath:       "sys/devices/pci0000:00/0000:00:14.0/usb1/1-2",
+				BusNum:          1,
+				DevNum:          5,
+				PortPath:        "1-2",
+				Speed:           "12",
+				Manufacturer:    "©Microsoft Corporation",
+				Product:         "Controller",
+				Serial:          "0843E1B",
+				BCDDevice:       0x0114,
+				InterfaceNumber: 0,
+			},
+		},
+		{
+			fixture: "xbox360-wireless",
+			want: USBInfo{
+				SysfsPath:       "sys/devices/pci0000:00/0000:00:14.0/usb1/1-3",
+				BusNum:          1,
+				DevNum:          7,
+				PortPath:        "1-3",
+				Speed:           "12",
+				Manufacturer:    "©Microsoft Corporation",
+				Product:         "Xbox 360 Wireless Receiver for Windows",
+				Serial:          "E02F1950",
+				BCDDevice:       0x0100,
+				InterfaceNumber: 0,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			root := loadSysfsFixture(t, tc.fixture)
+			infos, err := NewDiscoverer(root).FindXpadDevices()
+			if err != nil || len(infos) != 1 {
+				t.Fatalf("FindXpadDevices() = %d, %v", len(infos), err)
+			}
+			want := tc.want
+			want.SysfsPath = filepath.Join(root, want.SysfsPath)
+			if got := infos[0].USB; got != want {
+				t.Fatalf("USB = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	infos, err := NewDiscoverer(loadSysfsFixture(t, "xbox360-wired")).ListDevices()
+	if err != nil {
+		t.Fatalf("ListDevices() error: %v", err)
+	}
+	for _, info := range infos {
+		if info.Name == "Power Button" && info.USB != (USBInfo{}) {
+			t.Fatalf("non-USB device has USB info %+v", info.USB)
+		}
+	}
+}
+
+func TestDiscovererStableIdentity(t *testing.T) {
+	root := loadSysfsFixture(t, "xbox360-wired")
+	d := NewDiscoverer(root)
+	infos, err := d.FindXpadDevices()
+	if err != nil || len(infos) != 1 {
+		t.Fatalf("FindXpadDevices() = %d, %v", len(infos), err)
+	}
+	info := infos[0]
+
+	byID := filepath.Join(root, "dev/input/by-id/usb-©Microsoft_Corporation_Controller_0843E1B")
+	wantByID := []string{byID + "-event-joystick", byID + "-joystick"}
+	if !slices.Equal(info.ByID, wantByID) {
+		t.Fatalf("ByID = %q, want %q", info.ByID, wantByID)
+	}
+	byPath := filepath.Join(root, "dev/input/by-path/pci-0000:00:14.0-usb-0:2:1.0")
+	wantByPath := []string{byPath + "-event-joystick", byPath + "-joystick"}
+	if !slices.Equal(info.ByPath, wantByPath) {
+		t.Fatalf("ByPath = %q, want %q", info.ByPath, wantByPath)
+	}
+
+	const wantID = "045e:028e/serial:0843E1B/if0"
+	if got := info.StableID(); got != wantID {
+		t.Fatalf("StableID() = %q, want %q", got, wantID)
+	}
+	found, err := d.FindByStableID(wantID)
+	if err != nil {
+		t.Fatalf("FindByStableID() error: %v", err)
+	}
+	if found.Path != info.Path {
+		t.Fatalf("FindByStableID() path = %q, want %q", found.Path, info.Path)
+	}
+	if _, err := d.FindByStableID("045e:028e/serial:missing/if0"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("FindByStableID(missing) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDiscovererQueryNil(t *testing.T) {
+	d := NewDiscoverer(loadSysfsFixture(t, "xbox360-wired"))
+	all, err := d.ListDevices()
+	if err != nil {
+		t.Fatalf("ListDevices() error: %v", err)
+	}
+	infos, err := d.QueryDevices(nil)
+	if err != nil || len(infos) != len(all) {
+		t.Fatalf("QueryDevices(nil) = %d devices, %v, want %d", len(infos), err, len(all))
+	}
+}
+
+func TestDiscovererStableIdentityAmbiguous(t *testing.T) {
+	root := loadSysfsFixture(t, "procfs-only")
+	// A second identical pad without a serial on the same port path.
+	procPath := filepath.Join(root, "proc/bus/input/devices")
+	data, err := os.ReadFile(procPath)
+	if err != nil {
+		t.Fatalf("read proc devices: %v", err)
+	}
+	twin := strings.ReplaceAll(strings.ReplaceAll(string(data[strings.Index(string(data), "I: Bus=0003"):]),
+		"event5", "event6"), "js0", "js1")
+	mustWrite(t, procPath, string(data)+"\n"+twin)
+
+	d := NewDiscoverer(root)
+	infos, err := d.FindXpadDevices()
+	if err != nil || len(infos) != 2 {
+		t.Fatalf("FindXpadDevices() = %d, %v, want 2", len(infos), err)
+	}
+	id := infos[0].StableID()
+	if id == "" || infos[1].StableID() != id {
+		t.Fatalf("StableID() = %q and %q, want equal", id, infos[1].StableID())
+	}
+	if _, err := d.FindByStableID(id); !errors.Is(err, ErrAmbiguous) {
+		t.Fatalf("FindByStableID(%q) error = %v, want ErrAmbiguous", id, err)
+	}
+}
+
+func TestDiscovererProcFallbackError(t *testing.T) {
+	root := loadSysfsFixture(t, "procfs-only")
+	if err := os.Remove(filepath.Join(root, "proc/bus/input/devices")); err != nil {
+		t.Fatalf("remove proc devices: %v", err)
+	}
+	infos, err := NewDiscoverer(root).ListDevices()
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("ListDevices() = %d devices, %v, want ErrNotExist", len(infos), err)
+	}
+}
+
+func TestDiscovererProcFallback(t *testing.T) {
+	root := loadSysfsFixture(t, "procfs-only")
+	infos, err := NewDiscoverer(root).ListDevices()
+	if err != nil {
+		t.Fatalf("ListDevices() error: %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("ListDevices() returned %d devices, want 2", len(infos))
+	}
+
+	pad := infos[1]
+	if pad.Path != filepath.Join(root, "dev/input/event5") {
+		t.Fatalf("Path = %q, want event5", pad.Path)
+	}
+	if pad.JoystickPath != filepath.Join(root, "dev/input/js0") {
+		t.Fatalf("JoystickPath = %q, want js0", pad.JoystickPath)
+	}
+	if want := []string{"event5", "js0"}; !slices.Equal(pad.Handlers, want) {
+		t.Fatalf("Handlers = %q, want %q", pad.Handlers, want)
+	}
+	if pad.Name != "Microsoft X-Box 360 pad" || pad.VendorID != 0x045e || pad.ProductID != 0x028e || pad.BusType != BusUSB {
+		t.Fatalf("identity = %q %04x:%04x bus %#x", pad.Name, pad.VendorID, pad.ProductID, pad.BusType)
+	}
+	if !pad.IsXpad() || !pad.IsGamepad() {
+		t.Fatalf("IsXpad() = %v, IsGamepad() = %v, want true", pad.IsXpad(), pad.IsGamepad())
+	}
+	if !pad.Capabilities.HasEventCode(EVFF, FFRumble) {
+		t.Fatalf("HasEventCode(EVFF, FFRumble) = false, want true")
+	}
+	if len(pad.ByID) != 2 {
+		t.Fatalf("ByID = %q, want 2 links", pad.ByID)
+	}
+	if infos[0].Name != "Power Button" || !infos[0].Capabilities.HasEventCode(EVKey, 116) {
+		t.Fatalf("infos[0] = %q, want Power Button with KEY_POWER", infos[0].Name)
+	}
+}
+
+func TestDiscovererUdevData(t *testing.T) {
+	root := loadSysfsFixture(t, "xbox360-wired")
+	infos, err := NewDiscoverer(root).ListDevices()
+	if err != nil || len(infos) != 2 {
+		t.Fatalf("ListDevices() = %d, %v", len(infos), err)
+	}
+	button, pad := infos[0], infos[1]
+
+	if !pad.Udev.IsJoystick() || !pad.JoystickUdev.IsJoystick() {
+		t.Fatalf("IsJoystick() = %v/%v, want true", pad.Udev.IsJoystick(), pad.JoystickUdev.IsJoystick())
+	}
+	props := map[string]string{
+		"ID_SERIAL":               "©Microsoft_Corporation_Controller_0843E1B",
+		"ID_PATH":                 "pci-0000:00:14.0-usb-0:2:1.0",
+		"ID_VENDOR_FROM_DATABASE": "Microsoft Corp.",
+	}
+	for key, want := range props {
+		if got := pad.Udev.Property(key); got != want {
+			t.Fatalf("Property(%s) = %q, want %q", key, got, want)
+		}
+	}
+	if !pad.Udev.HasTag("uaccess") {
+		t.Fatalf("Tags = %q, want uaccess", pad.Udev.Tags)
+	}
+	if pad.Seat() != "seat1" || pad.JoystickUdev.Seat() != "seat1" {
+		t.Fatalf("Seat() = %q/%q, want seat1", pad.Seat(), pad.JoystickUdev.Seat())
+	}
+	if button.Seat() != "" || !button.Udev.HasTag("power-switch") {
+		t.Fatalf("button Seat() = %q, Tags = %q", button.Seat(), button.Udev.Tags)
+	}
+
+	if _, err := NewQuery().Seat("seat1").Filter(infos); err != nil {
+		t.Fatalf("Filter(Seat(seat1)) error: %v", err)
+	}
+	if _, err := NewQuery().Seat("seat0").Filter(infos); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Filter(Seat(seat0)) error = %v, want ErrNotFound", err)
+	}
+}
@@ -16,3 +16,63 @@ func FindXpadDevices() ([]DeviceInfo, error) {
 func OpenFirstXpad() (*Device, error) {
 	return nil, ErrNotImplemented
 }
+
+// ListDevices is not supported on non-Linux platforms.
+func (d *Discoverer) ListDevices() ([]DeviceInfo, error) {
+	return nil, ErrNotImplemented
+}
+
+// FindXpadDevices is not supported on non-Linux platforms.
+func (d *Discoverer) FindXpadDevices() ([]DeviceInfo, error) {
+	return nil, ErrNotImplemented
+}
+
+// OpenFirstXpad is not supported on non-Linux platforms.
+func (d *Discoverer) OpenFirstXpad() (*Device, error) {
+	return nil, ErrNotImplemented
+}
+
+// ListControllers is not supported on non-Linux platforms.
+func ListControllers() ([]Controller, error) {
+	return nil, ErrNotImplemented
+}
+
+// ListControllers is not supported on non-Linux platforms.
+func (d *Discoverer) ListControllers() ([]Controller, error) {
+	return nil, ErrNotImplemented
+}
+
+// OpenByStableID is not supported on non-Linux platforms.
+func OpenByStableID(id string) (*Device, error) {
+	return nil, ErrNotImplemented
+}
+
+// FindByStableID is not supported on non-Linux platforms.
+func (d *Discoverer) FindByStableID(id string) (DeviceInfo, error) {
+	return DeviceInfo{}, ErrNotImplemented
+}
+
+// OpenByStableID is not supported on non-Linux platforms.
+func (d *Discoverer) OpenByStableID(id string) (*Device, error) {
+	return nil, ErrNotImplemented
+}
+
+// QueryDevices is not supported on non-Linux platforms.
+func QueryDevices(q *Query) ([]DeviceInfo, error) {
+	return nil, ErrNotImplemented
+}
+
+// OpenFirst is not supported on non-Linux platforms.
+func OpenFirst(q *Query) (*Device, error) {
+	return nil, ErrNotImplemented
+}
+
+// QueryDevices is not supported on non-Linux platforms.
+func (d *Discoverer) QueryDevices(q *Query) ([]DeviceInfo, error) {
+	return nil, ErrNotImplemented
+}
+
+// OpenFirst is not supported on non-Linux platforms.
+func (d *Discoverer) OpenFirst(q *Query) (*Device, error) {
+	return nil, ErrNotImplemented
+}
@@ -0,0 +1,201 @@
+package xpad
+
+// DpadForm is how a device reports its D-pad. It depends on the controller
+// family and on the dpad_to_buttons module parameter.
+type DpadForm uint8
+
+const (
+	DpadFormUnknown DpadForm = iota
+	// DpadFormHat reports ABSHat0X/ABSHat0Y in [-1,1].
+	DpadFormHat
+	// DpadFormButtons reports BTNDPadUp/Down/Left/Right.
+	DpadFormButtons
+	// DpadFormTriggerHappy reports BTNTriggerHappy1-4 as left, right, up and
+	// down, as xpad does for wireless 360 pads with dpad_to_buttons.
+	DpadFormTriggerHappy
+)
+
+// String returns a short name for the form.
+func (f DpadForm) String() string {
+	switch f {
+	case DpadFormHat:
+		return "hat"
+	case DpadFormButtons:
+		return "buttons"
+	case DpadFormTriggerHappy:
+		return "trigger-happy"
+	default:
+		return "unknown"
+	}
+}
+
+// DetectDpadForm returns the D-pad form a device advertises. A hat takes
+// precedence over buttons.
+func DetectDpadForm(caps Capabilities) DpadForm {
+	switch {
+	case caps.HasEventCode(EVAbs, ABSHat0X) && caps.HasEventCode(EVAbs, ABSHat0Y):
+		return DpadFormHat
+	case caps.HasEventCode(EVKey, BTNDPadUp):
+		return DpadFormButtons
+	case caps.HasEventCode(EVKey, BTNTriggerHappy1) && caps.HasEventCode(EVKey, BTNTriggerHappy4):
+		return DpadFormTriggerHappy
+	default:
+		return DpadFormUnknown
+	}
+}
+
+// DpadDirection is an 8-way D-pad direction.
+type DpadDirection uint8
+
+const (
+	DpadCentered DpadDirection = iota
+	DpadUp
+	DpadUpRight
+	DpadRight
+	DpadDownRight
+	DpadDown
+	DpadDownLeft
+	DpadLeft
+	DpadUpLeft
+)
+
+var dpadDirectionNames = [...]string{
+	DpadCentered:  "centered",
+	DpadUp:        "up",
+	DpadUpRight:   "up-right",
+	DpadRight:     "right",
+	DpadDownRight: "down-right",
+	DpadDown:      "down",
+	DpadDownLeft:  "down-left",
+	DpadLeft:      "left",
+	DpadUpLeft:    "up-left",
+}
+
+// String returns the direction name, e.g. "up-left".
+func (d DpadDirection) String() string {
+	if int(d) < len(dpadDirectionNames) {
+		return dpadDirectionNames[d]
+	}
+	return "unknown"
+}
+
+// DpadState is the four-button state of a D-pad.
+type DpadState struct {
+	Up, Down, Left, Right bool
+}
+
+// Direction returns the 8-way direction. Opposite directions held together
+// cancel out.
+func (s DpadState) Direction() DpadDirection {
+	x, y := 0, 0
+	if s.Left {
+		x--
+	}
+	if s.Right {
+		x++
+	}
+	if s.Up {
+		y--
+	}
+	if s.Down {
+		y++
+	}
+	switch {
+	case y < 0 && x == 0:
+		return DpadUp
+	case y < 0 && x > 0:
+		return DpadUpRight
+	case y == 0 && x > 0:
+		return DpadRight
+	case y > 0 && x > 0:
+		return DpadDownRight
+	case y > 0 && x == 0:
+		return DpadDown
+	case y > 0 && x < 0:
+		return DpadDownLeft
+	case y == 0 && x < 0:
+		return DpadLeft
+	case y < 0 && x < 0:
+		return DpadUpLeft
+	default:
+		return DpadCentered
+	}
+}
+
+// DpadDecoder turns D-pad events of any form into a DpadState, so callers do
+// not depend on the module parameters or the controller family.
+type DpadDecoder struct {
+	// Form is the detected form. The decoder accepts only the codes of that
+	// form, so that extra trigger-happy buttons on a hat device are not read
+	// as D-pad presses; with DpadFormUnknown it accepts every form.
+	Form DpadForm
+
+	state DpadState
+}
+
+// NewDpadDecoder returns a decoder for a device with the given capabilities.
+func NewDpadDecoder(caps Capabilities) *DpadDecoder {
+	return &DpadDecoder{Form: DetectDpadForm(caps)}
+}
+
+// State returns the current D-pad state.
+func (d *DpadDecoder) State() DpadState {
+	return d.state
+}
+
+// Update applies an event and returns the new state. ok is false for events
+// that are not part of the D-pad; changed reports whether the state changed.
+func (d *DpadDecoder) Update(ev Event) (state DpadState, changed, ok bool) {
+	if !d.accepts(ev) {
+		return d.state, false, false
+	}
+	old := d.state
+	switch ev.Kind {
+	case EVAbs:
+		switch ev.Code {
+		case ABSHat0X:
+			d.state.Left, d.state.Right = ev.Value < 0, ev.Value > 0
+		case ABSHat0Y:
+			d.state.Up, d.state.Down = ev.Value < 0, ev.Value > 0
+		default:
+			return d.state, false, false
+		}
+	case EVKey:
+		// Autorepeats keep the button held.
+		pressed := ev.Value != KeyReleased
+		switch ev.Code {
+		case BTNDPadUp, BTNTriggerHappy3:
+			d.state.Up = pressed
+		case BTNDPadDown, BTNTriggerHappy4:
+			d.state.Down = pressed
+		case BTNDPadLeft, BTNTriggerHappy1:
+			d.state.Left = pressed
+		case BTNDPadRight, BTNTriggerHappy2:
+			d.state.Right = pressed
+		default:
+			return d.state, false, false
+		}
+	default:
+		return d.state, false, false
+	}
+	return d.state, d.state != old, true
+}
+
+// accepts reports whether ev may belong to the D-pad of the decoder's form.
+func (d *DpadDecoder) accepts(ev Event) bool {
+	switch d.Form {
+	case DpadFormHat:
+		return ev.Kind == EVAbs
+	case DpadFormButtons:
+		return ev.Kind == EVKey && ev.Code >= BTNDPadUp && ev.Code <= BTNDPadRight
+	case DpadFormTriggerHappy:
+		return ev.Kind == EVKey && ev.Code >= BTNTriggerHappy1 && ev.Code <= BTNTriggerHappy4
+	default:
+		return true
+	}
+}
+
+// Reset centers the D-pad.
+func (d *DpadDecoder) Reset() {
+	d.state = DpadState{}
+}
@@ -0,0 +1,16 @@
+//go:build linux
+
+package xpad
+
+// DpadDecoder reads the device's key and absolute axis capabilities and
+// returns a DpadDecoder for its events.
+func (d *Device) DpadDecoder() (*DpadDecoder, error) {
+	if d == nil || d.file == nil {
+		return nil, ErrClosed
+	}
+	caps, err := d.capabilities(EVAbs, EVKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewDpadDecoder(caps), nil
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package xpad
+
+// DpadDecoder is not supported on non-Linux platforms.
+func (d *Device) DpadDecoder() (*DpadDecoder, error) {
+	return nil, ErrNotImplemented
+}
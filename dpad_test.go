@@ -0,0 +1,114 @@
+package xpad
+
+import "testing"
+
+func TestDetectDpadForm(t *testing.T) {
+	cases := []struct {
+		name string
+		abs  []uint16
+		keys []uint16
+		want DpadForm
+	}{
+		{"hat", []uint16{ABSX, ABSHat0X, ABSHat0Y}, []uint16{BTNA}, DpadFormHat},
+		{"buttons", []uint16{ABSX}, []uint16{BTNA, BTNDPadUp, BTNDPadRight}, DpadFormButtons},
+		{"trigger happy", []uint16{ABSX}, []uint16{BTNA, BTNTriggerHappy1, BTNTriggerHappy4}, DpadFormTriggerHappy},
+		{"none", []uint16{ABSX}, []uint16{BTNA}, DpadFormUnknown},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var caps Capabilities
+			for _, code := range tc.abs {
+				caps.setCode(EVAbs, code)
+			}
+			for _, code := range tc.keys {
+				caps.setCode(EVKey, code)
+			}
+			if got := DetectDpadForm(caps); got != tc.want {
+				t.Fatalf("DetectDpadForm = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDpadDecoder(t *testing.T) {
+	type step struct {
+		ev      Event
+		want    DpadDirection
+		changed bool
+	}
+	cases := []struct {
+		name  string
+		form  DpadForm
+		steps []step
+	}{
+		{
+			name: "hat",
+			form: DpadFormHat,
+			steps: []step{
+				{Event{Kind: EVAbs, Code: ABSHat0Y, Value: -1}, DpadUp, true},
+				{Event{Kind: EVAbs, Code: ABSHat0X, Value: 1}, DpadUpRight, true},
+				{Event{Kind: EVAbs, Code: ABSHat0Y, Value: 1}, DpadDownRight, true},
+				{Event{Kind: EVAbs, Code: ABSHat0X, Value: 1}, DpadDownRight, false},
+				{Event{Kind: EVAbs, Code: ABSHat0X, Value: 0}, DpadDown, true},
+				{Event{Kind: EVAbs, Code: ABSHat0Y, Value: 0}, DpadCentered, true},
+			},
+		},
+		{
+			name: "buttons",
+			form: DpadFormButtons,
+			steps: []step{
+				{Event{Kind: EVKey, Code: BTNDPadLeft, Value: KeyPressed}, DpadLeft, true},
+				{Event{Kind: EVKey, Code: BTNDPadDown, Value: KeyPressed}, DpadDownLeft, true},
+				{Event{Kind: EVKey, Code: BTNDPadDown, Value: KeyRepeated}, DpadDownLeft, false},
+				{Event{Kind: EVKey, Code: BTNDPadRight, Value: KeyPressed}, DpadDown, true},
+				{Event{Kind: EVKey, Code: BTNDPadDown, Value: KeyReleased}, DpadCentered, true},
+			},
+		},
+		{
+			name: "trigger happy",
+			form: DpadFormTriggerHappy,
+			steps: []step{
+				{Event{Kind: EVKey, Code: BTNTriggerHappy3, Value: KeyPressed}, DpadUp, true},
+				{Event{Kind: EVKey, Code: BTNTriggerHappy1, Value: KeyPressed}, DpadUpLeft, true},
+				{Event{Kind: EVKey, Code: BTNTriggerHappy3, Value: KeyReleased}, DpadLeft, true},
+				{Event{Kind: EVKey, Code: BTNTriggerHappy1, Value: KeyReleased}, DpadCentered, true},
+				{Event{Kind: EVKey, Code: BTNTriggerHappy4, Value: KeyPressed}, DpadDown, true},
+				{Event{Kind: EVKey, Code: BTNTriggerHappy2, Value: KeyPressed}, DpadDownRight, true},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, form := range []DpadForm{DpadFormUnknown, tc.form} {
+				d := DpadDecoder{Form: form}
+				for _, s := range tc.steps {
+					state, changed, ok := d.Update(s.ev)
+					if !ok || state.Direction() != s.want || changed != s.changed {
+						t.Fatalf("%s: Update(%+v) = %v, %v, %v, want %v, %v", form, s.ev, state.Direction(), changed, ok, s.want, s.changed)
+					}
+				}
+			}
+		})
+	}
+
+	var d DpadDecoder
+	if _, _, ok := d.Update(Event{Kind: EVKey, Code: BTNA, Value: KeyPressed}); ok {
+		t.Fatalf("Update(BTNA) ok = true, want false")
+	}
+	if _, _, ok := d.Update(Event{Kind: EVAbs, Code: ABSX, Value: 100}); ok {
+		t.Fatalf("Update(ABSX) ok = true, want false")
+	}
+
+	// A hat device with extra trigger-happy buttons.
+	hat := DpadDecoder{Form: DpadFormHat}
+	if state, _, ok := hat.Update(Event{Kind: EVKey, Code: BTNTriggerHappy1, Value: KeyPressed}); ok || state.Left {
+		t.Fatalf("hat Update(BTNTriggerHappy1) = %+v, %v, want not part of the D-pad", state, ok)
+	}
+	if _, _, ok := hat.Update(Event{Kind: EVKey, Code: BTNDPadUp, Value: KeyPressed}); ok {
+		t.Fatalf("hat Update(BTNDPadUp) ok = true, want false")
+	}
+	buttons := DpadDecoder{Form: DpadFormButtons}
+	if _, _, ok := buttons.Update(Event{Kind: EVAbs, Code: ABSHat0X, Value: 1}); ok {
+		t.Fatalf("buttons Update(ABSHat0X) ok = true, want false")
+	}
+}
@@ -5,8 +5,10 @@ package xpad
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
+	"runtime"
 	"syscall"
 	"time"
 	"unsafe"
@@ -32,10 +34,22 @@ func evioCGID() uint {
 	return ioctl.IOR(evdevIOCBase, 0x02, ioctl.Size(InputID{}))
 }
 
+func evioCGREP() uint {
+	return ioctl.IOR(evdevIOCBase, 0x03, ioctl.Size([2]uint32{}))
+}
+
+func evioCSREP() uint {
+	return ioctl.IOW(evdevIOCBase, 0x03, ioctl.Size([2]uint32{}))
+}
+
 func evioCGBIT(ev EventKind, length uint) uint {
 	return ioctl.IOC(ioctl.DirRead, evdevIOCBase, uint(0x20)+uint(ev), length)
 }
 
+func evioCGKEY(length uint) uint {
+	return ioctl.IOC(ioctl.DirRead, evdevIOCBase, 0x18, length)
+}
+
 func evioCGABS(code uint16) uint {
 	return ioctl.IOR(evdevIOCBase, uint(0x40)+uint(code), ioctl.Size(AbsInfo{}))
 }
@@ -56,6 +70,28 @@ func evioCGRAB() uint {
 	return ioctl.IOW(evdevIOCBase, 0x90, ioctl.Size(int32(0)))
 }
 
+func evioCSMASK() uint {
+	return ioctl.IOW(evdevIOCBase, 0x93, ioctl.Size(inputMask{}))
+}
+
+func evioCSCLOCKID() uint {
+	return ioctl.IOW(evdevIOCBase, 0xa0, ioctl.Size(int32(0)))
+}
+
+// inputMask mirrors struct input_mask.
+type inputMask struct {
+	Type      uint32
+	CodesSize uint32
+	CodesPtr  uint64
+}
+
+// Linux clock IDs accepted by EVIOCSCLOCKID.
+const (
+	clockRealtime  = 0
+	clockMonotonic = 1
+	clockBoottime  = 7
+)
+
 // Name returns the evdev device name.
 func (d *Device) Name() (string, error) {
 	return getStringIoctl(d, evioCGNAME)
@@ -97,6 +133,20 @@ func (d *Device) AbsInfo(code uint16) (AbsInfo, error) {
 	return info, nil
 }
 
+// KeyState returns a bitset of the keys and buttons currently held, in the
+// layout of EventTypes.
+func (d *Device) KeyState() ([]byte, error) {
+	if d == nil || d.file == nil {
+		return nil, ErrClosed
+	}
+	buf := make([]byte, bitsetBytes(KeyMax))
+	fd, _ := d.FD()
+	if err := ioctl.CallPtr(fd, evioCGKEY(uint(len(buf))), unsafe.Pointer(&buf[0])); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
 // EventTypes returns a bitset of supported event types.
 func (d *Device) EventTypes() ([]byte, error) {
 	return d.eventBitset(0, EVMax)
@@ -113,18 +163,9 @@ func (d *Device) HasEventType(ev EventKind) (bool, error) {
 
 // HasEventCode reports whether the device supports the provided event code.
 func (d *Device) HasEventCode(ev EventKind, code uint16) (bool, error) {
-	var max uint16
-	switch ev {
-	case EVKey:
-		max = KeyMax
-	case EVAbs:
-		max = AbsMax
-	case EVFF:
-		max = FFMax
-	case EVLed:
-		max = LEDMax
-	default:
-		return false, fmt.Errorf("xpad: unsupported event type %d", ev)
+	max, err := eventCodeMax(ev)
+	if err != nil {
+		return false, err
 	}
 	bits, err := d.eventBitset(ev, max)
 	if err != nil {
@@ -146,6 +187,50 @@ func (d *Device) EffectCount() (int, error) {
 	return int(count), nil
 }
 
+// Repeat returns the kernel autorepeat delay and period.
+func (d *Device) Repeat() (RepeatSettings, error) {
+	if d == nil || d.file == nil {
+		return RepeatSettings{}, ErrClosed
+	}
+	fd, _ := d.FD()
+	var rep [2]uint32
+	if err := ioctl.CallPtr(fd, evioCGREP(), unsafe.Pointer(&rep[0])); err != nil {
+		return RepeatSettings{}, err
+	}
+	return RepeatSettings{
+		Delay:  time.Duration(rep[RepDelay]) * time.Millisecond,
+		Period: time.Duration(rep[RepPeriod]) * time.Millisecond,
+	}, nil
+}
+
+// SetRepeat updates the kernel autorepeat delay and period.
+//
+// The kernel only autorepeats devices that advertise EV_REP; others fail with
+// ENOSYS. A zero Period disables repeat.
+func (d *Device) SetRepeat(rep RepeatSettings) error {
+	if d == nil || d.file == nil {
+		return ErrClosed
+	}
+	if d.readOnly {
+		return ErrReadOnly
+	}
+	if rep.Delay < 0 || rep.Period < 0 {
+		return fmt.Errorf("xpad: negative repeat settings %+v", rep)
+	}
+	fd, _ := d.FD()
+	value := [2]uint32{
+		RepDelay:  uint32(rep.Delay / time.Millisecond),
+		RepPeriod: uint32(rep.Period / time.Millisecond),
+	}
+	if err := ioctl.CallPtr(fd, evioCSREP(), unsafe.Pointer(&value[0])); err != nil {
+		if errors.Is(err, syscall.ENOSYS) {
+			return fmt.Errorf("xpad: device does not support key repeat: %w", err)
+		}
+		return err
+	}
+	return nil
+}
+
 // Grab enables or disables exclusive access to the device.
 func (d *Device) Grab(grab bool) error {
 	if d == nil || d.file == nil {
@@ -159,6 +244,80 @@ func (d *Device) Grab(grab bool) error {
 	return ioctl.CallPtr(fd, evioCGRAB(), unsafe.Pointer(&value))
 }
 
+// SetEventMask limits the codes of ev delivered to this handle to codes.
+// Use EVSyn to mask whole event types. An empty codes slice blocks every code.
+func (d *Device) SetEventMask(ev EventKind, codes []uint16) error {
+	if d == nil || d.file == nil {
+		return ErrClosed
+	}
+	max := uint16(EVMax)
+	if ev != EVSyn {
+		var err error
+		if max, err = eventCodeMax(ev); err != nil {
+			return err
+		}
+	}
+	bits := make([]byte, bitsetBytes(max))
+	for _, code := range codes {
+		if code > max {
+			return fmt.Errorf("xpad: event code %d out of range for type %d", code, ev)
+		}
+		bits[code/8] |= 1 << (code % 8)
+	}
+	mask := inputMask{
+		Type:      uint32(ev),
+		CodesSize: uint32(len(bits)),
+		CodesPtr:  uint64(uintptr(unsafe.Pointer(&bits[0]))),
+	}
+	fd, _ := d.FD()
+	err := ioctl.CallPtr(fd, evioCSMASK(), unsafe.Pointer(&mask))
+	runtime.KeepAlive(bits)
+	return err
+}
+
+// SetClock selects the clock used for event timestamps on this handle.
+func (d *Device) SetClock(clock ClockID) error {
+	if d == nil || d.file == nil {
+		return ErrClosed
+	}
+	var value int32
+	switch clock {
+	case ClockDefault, ClockRealtime:
+		value = clockRealtime
+	case ClockMonotonic:
+		value = clockMonotonic
+	case ClockBoottime:
+		value = clockBoottime
+	default:
+		return fmt.Errorf("xpad: unsupported clock %d", clock)
+	}
+	fd, _ := d.FD()
+	return ioctl.CallPtr(fd, evioCSCLOCKID(), unsafe.Pointer(&value))
+}
+
+func eventCodeMax(ev EventKind) (uint16, error) {
+	switch ev {
+	case EVKey:
+		return KeyMax, nil
+	case EVRel:
+		return RelMax, nil
+	case EVAbs:
+		return AbsMax, nil
+	case EVMsc:
+		return MscMax, nil
+	case EVSw:
+		return SwMax, nil
+	case EVLed:
+		return LEDMax, nil
+	case EVSnd:
+		return SndMax, nil
+	case EVFF:
+		return FFMax, nil
+	default:
+		return 0, fmt.Errorf("xpad: unsupported event type %d", ev)
+	}
+}
+
 func (d *Device) eventBitset(ev EventKind, max uint16) ([]byte, error) {
 	if d == nil || d.file == nil {
 		return nil, ErrClosed
@@ -172,17 +331,34 @@ func (d *Device) eventBitset(ev EventKind, max uint16) ([]byte, error) {
 	return buf, nil
 }
 
-func bitsetBytes(max uint16) int {
-	return int(max/8) + 1
+// capabilities reads the code bitsets of the given event types.
+func (d *Device) capabilities(kinds ...EventKind) (Capabilities, error) {
+	caps := Capabilities{Codes: make(map[EventKind][]byte)}
+	for _, ev := range kinds {
+		max, err := eventCodeMax(ev)
+		if err != nil {
+			return Capabilities{}, err
+		}
+		bits, err := d.eventBitset(ev, max)
+		if err != nil {
+			return Capabilities{}, err
+		}
+		caps.Codes[ev] = bits
+	}
+	return caps, nil
 }
 
-func bitsetHas(bits []byte, code uint16) bool {
-	index := int(code / 8)
-	if index < 0 || index >= len(bits) {
-		return false
+// absInfos reads the AbsInfo of every advertised absolute axis.
+func (d *Device) absInfos(caps Capabilities) (map[uint16]AbsInfo, error) {
+	infos := make(map[uint16]AbsInfo)
+	for _, code := range caps.EventCodes(EVAbs) {
+		info, err := d.AbsInfo(code)
+		if err != nil {
+			return nil, err
+		}
+		infos[code] = info
 	}
-	mask := byte(1 << (code % 8))
-	return bits[index]&mask != 0
+	return infos, nil
 }
 
 func getStringIoctl(d *Device, reqFn func(uint) uint) (string, error) {
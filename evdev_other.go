@@ -19,6 +19,9 @@ func (d *Device) ID() (InputID, error) { return InputID{}, ErrNotImplemented }
 // AbsInfo is not supported on non-Linux platforms.
 func (d *Device) AbsInfo(code uint16) (AbsInfo, error) { return AbsInfo{}, ErrNotImplemented }
 
+// KeyState is not supported on non-Linux platforms.
+func (d *Device) KeyState() ([]byte, error) { return nil, ErrNotImplemented }
+
 // EventTypes is not supported on non-Linux platforms.
 func (d *Device) EventTypes() ([]byte, error) { return nil, ErrNotImplemented }
 
@@ -33,9 +36,21 @@ func (d *Device) HasEventCode(ev EventKind, code uint16) (bool, error) {
 // EffectCount is not supported on non-Linux platforms.
 func (d *Device) EffectCount() (int, error) { return 0, ErrNotImplemented }
 
+// Repeat is not supported on non-Linux platforms.
+func (d *Device) Repeat() (RepeatSettings, error) { return RepeatSettings{}, ErrNotImplemented }
+
+// SetRepeat is not supported on non-Linux platforms.
+func (d *Device) SetRepeat(rep RepeatSettings) error { return ErrNotImplemented }
+
 // Grab is not supported on non-Linux platforms.
 func (d *Device) Grab(grab bool) error { return ErrNotImplemented }
 
+// SetEventMask is not supported on non-Linux platforms.
+func (d *Device) SetEventMask(ev EventKind, codes []uint16) error { return ErrNotImplemented }
+
+// SetClock is not supported on non-Linux platforms.
+func (d *Device) SetClock(clock ClockID) error { return ErrNotImplemented }
+
 func readEvent(d *Device, timeout time.Duration) (Event, error) {
 	return Event{}, ErrNotImplemented
 }
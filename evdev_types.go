@@ -1,5 +1,7 @@
 package xpad
 
+import "time"
+
 // Event type constants (EV_*).
 const (
 	EVSyn      EventKind = 0x00
@@ -20,13 +22,29 @@ const (
 const (
 	EVMax   = 0x1f
 	KeyMax  = 0x2ff
+	RelMax  = 0x0f
 	AbsMax  = 0x3f
 	AbsCnt  = AbsMax + 1
+	MscMax  = 0x07
+	SwMax   = 0x10
 	FFMax   = 0x7f
 	LEDMax  = 0x0f
+	SndMax  = 0x07
+	PropMax = 0x1f
 	BtnMisc = 0x100
 )
 
+// Input device properties (INPUT_PROP_*).
+const (
+	InputPropPointer       = 0x00
+	InputPropDirect        = 0x01
+	InputPropButtonPad     = 0x02
+	InputPropSemiMT        = 0x03
+	InputPropTopButtonPad  = 0x04
+	InputPropPointingStick = 0x05
+	InputPropAccelerometer = 0x06
+)
+
 // Sync event codes (SYN_*).
 const (
 	SynReport   = 0
@@ -35,6 +53,19 @@ const (
 	SynDropped  = 3
 )
 
+// Key event values reported with EV_KEY.
+const (
+	KeyReleased = 0
+	KeyPressed  = 1
+	KeyRepeated = 2
+)
+
+// Autorepeat codes (REP_*), used with EV_REP.
+const (
+	RepDelay  = 0x00
+	RepPeriod = 0x01
+)
+
 // Absolute axis codes (ABS_*).
 const (
 	ABSX       = 0x00
@@ -43,17 +74,24 @@ const (
 	ABSRX      = 0x03
 	ABSRY      = 0x04
 	ABSRZ      = 0x05
+	ABSGas     = 0x09
+	ABSBrake   = 0x0a
 	ABSHat0X   = 0x10
 	ABSHat0Y   = 0x11
+	ABSHat3Y   = 0x17
 	ABSProfile = 0x21
 )
 
 // Button codes for common Xbox controller inputs (BTN_*).
 const (
+	BTNGamepad = 0x130
+
 	BTNA      = 0x130
 	BTNB      = 0x131
+	BTNC      = 0x132
 	BTNX      = 0x133
 	BTNY      = 0x134
+	BTNZ      = 0x135
 	BTNTL     = 0x136
 	BTNTR     = 0x137
 	BTNTL2    = 0x138
@@ -101,6 +139,21 @@ const (
 	FFAutocenter = 0x61
 )
 
+// Bus types (BUS_*) reported in InputID.BusType and DeviceInfo.BusType.
+const (
+	BusUSB       = 0x03
+	BusBluetooth = 0x05
+	BusVirtual   = 0x06
+)
+
+// RepeatSettings holds the kernel autorepeat configuration (EVIOCGREP).
+type RepeatSettings struct {
+	// Delay is the time a key must be held before it starts repeating.
+	Delay time.Duration
+	// Period is the interval between repeated events.
+	Period time.Duration
+}
+
 // InputID mirrors struct input_id.
 type InputID struct {
 	BusType uint16
@@ -1,15 +1,34 @@
 package xpad
 
-import "os"
+import (
+	"os"
+	"syscall"
+)
 
-func openReadWriteOrReadOnly(path string) (*os.File, bool, error) {
-	file, err := os.OpenFile(path, os.O_RDWR, 0)
+// openWithOptions opens path according to opts and reports whether the handle
+// is read-only and whether that was a fallback from a failed read-write open.
+func openWithOptions(path string, opts Options) (*os.File, bool, bool, error) {
+	extra := 0
+	if opts.NonBlocking {
+		extra |= syscall.O_NONBLOCK
+	}
+	if opts.Access == AccessReadOnly {
+		file, err := os.OpenFile(path, os.O_RDONLY|extra, 0)
+		if err != nil {
+			return nil, false, false, err
+		}
+		return file, true, false, nil
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|extra, 0)
 	if err == nil {
-		return file, false, nil
+		return file, false, false, nil
+	}
+	if opts.Fallback == FallbackNone {
+		return nil, false, false, err
 	}
-	file, err = os.OpenFile(path, os.O_RDONLY, 0)
+	file, err = os.OpenFile(path, os.O_RDONLY|extra, 0)
 	if err != nil {
-		return nil, false, err
+		return nil, false, false, err
 	}
-	return file, true, nil
+	return file, true, true, nil
 }
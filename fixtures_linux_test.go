@@ -0,0 +1,99 @@
+//go:build linux
+
+package xpad
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// loadSysfsFixture materializes testdata/sysfs/<name>.txtar into a temporary
+// directory and returns it for use as a Discoverer root. The archives hold
+// "-- path --" file entries, "-- path/ --" empty directories and
+// "-- path -> target --" symlinks, since sysfs names are not valid module paths.
+func loadSysfsFixture(t *testing.T, name string) string {
+	t.Helper()
+
+	archive, err := os.Open(filepath.Join("testdata", "sysfs", name+".txtar"))
+	if err != nil {
+		t.Fatalf("open fixture %s: %v", name, err)
+	}
+	defer archive.Close()
+
+	root := t.TempDir()
+	var current string
+	var content strings.Builder
+	flush := func() {
+		if current == "" {
+			return
+		}
+		path := filepath.Join(root, current)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content.String()), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		current = ""
+		content.Reset()
+	}
+
+	scanner := bufio.NewScanner(archive)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if header, ok := strings.CutPrefix(line, "-- "); ok && strings.HasSuffix(header, " --") {
+			flush()
+			header = strings.TrimSuffix(header, " --")
+			if link, target, ok := strings.Cut(header, " -> "); ok {
+				path := filepath.Join(root, link)
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Symlink(target, path); err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+			if strings.HasSuffix(header, "/") {
+				if err := os.MkdirAll(filepath.Join(root, header), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				continue
+			}
+			current = header
+			continue
+		}
+		if current != "" {
+			content.WriteString(line)
+			content.WriteByte('\n')
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("read fixture %s: %v", name, err)
+	}
+	flush()
+	return root
+}
+
+func mustWrite(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func mustSymlink(t *testing.T, target, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(target, path); err != nil {
+		t.Fatal(err)
+	}
+}
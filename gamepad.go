@@ -0,0 +1,258 @@
+package xpad
+
+import (
+	"sort"
+	"time"
+)
+
+// GamepadState is a snapshot of the logical controls. Stick axes are in
+// [-1,1] with positive X right and positive Y down, as evdev reports them;
+// triggers are in [0,1].
+type GamepadState struct {
+	When    time.Time
+	Buttons [ButtonCount]bool
+	Axes    [AxisCount]float32
+}
+
+// ControlEvent is a change of one logical control.
+type ControlEvent struct {
+	When time.Time
+	// IsAxis selects Axis and Value; otherwise Button and Pressed apply.
+	IsAxis  bool
+	Button  Button
+	Pressed bool
+	Axis    Axis
+	Value   float32
+}
+
+// setButton updates a button and reports whether it changed.
+func (s *GamepadState) setButton(when time.Time, b Button, pressed bool) (ControlEvent, bool) {
+	s.When = when
+	if s.Buttons[b] == pressed {
+		return ControlEvent{}, false
+	}
+	s.Buttons[b] = pressed
+	return ControlEvent{When: when, Button: b, Pressed: pressed}, true
+}
+
+// setAxis updates an axis and reports whether it changed.
+func (s *GamepadState) setAxis(when time.Time, a Axis, value float32) (ControlEvent, bool) {
+	s.When = when
+	if s.Axes[a] == value {
+		return ControlEvent{}, false
+	}
+	s.Axes[a] = value
+	return ControlEvent{When: when, IsAxis: true, Axis: a, Value: value}, true
+}
+
+// GamepadTracker builds a GamepadState from evdev events without an SDL
+// mapping, using MapButton, MapAxis, a Normalizer and a DpadDecoder. It
+// handles every D-pad form and both analog and digital triggers.
+type GamepadTracker struct {
+	Family ControllerFamily
+
+	norm  *Normalizer
+	dpad  DpadDecoder
+	state GamepadState
+	// dropping is set from SYN_DROPPED to the next SYN_REPORT, stale from
+	// then until Sync.
+	dropping, stale bool
+}
+
+// NewGamepadTracker returns a tracker for a controller of the family. norm
+// normalizes the axes, e.g. from Device.Normalizer; with a nil norm axis
+// events are ignored. The state starts at rest; seed it with Sync, or use
+// Device.GamepadTracker, which does.
+func NewGamepadTracker(family ControllerFamily, norm *Normalizer) *GamepadTracker {
+	return &GamepadTracker{Family: family, norm: norm}
+}
+
+// State returns the current state of the logical controls.
+func (t *GamepadTracker) State() GamepadState {
+	return t.state
+}
+
+// Stale reports whether the kernel dropped events (SYN_DROPPED) and the
+// state must be resynced with Sync, e.g. through Device.SyncGamepadTracker.
+func (t *GamepadTracker) Stale() bool {
+	return t.stale
+}
+
+// Sync sets the state from a snapshot of the device and returns the controls
+// that changed. keys is the bitset of held keys, as from Device.KeyState, and
+// axes holds the current raw value of each absolute axis, as AbsInfo.Value.
+func (t *GamepadTracker) Sync(when time.Time, keys []byte, axes map[uint16]int32) []ControlEvent {
+	t.dropping, t.stale = false, false
+	old := t.state
+	// Releases go first so that a released code of one D-pad form cannot
+	// undo a held code of another.
+	for _, pressed := range []bool{false, true} {
+		for code := uint16(0); code <= KeyMax; code++ {
+			if bitsetHas(keys, code) != pressed {
+				continue
+			}
+			value := int32(KeyReleased)
+			if pressed {
+				value = KeyPressed
+			}
+			t.Apply(Event{When: when, Kind: EVKey, Code: code, Value: value})
+		}
+	}
+	codes := make([]uint16, 0, len(axes))
+	for code := range axes {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool { return codes[i] < codes[j] })
+	for _, code := range codes {
+		t.Apply(Event{When: when, Kind: EVAbs, Code: code, Value: axes[code]})
+	}
+	t.state.When = when
+
+	var changes []ControlEvent
+	for b := range t.state.Buttons {
+		if pressed := t.state.Buttons[b]; pressed != old.Buttons[b] {
+			changes = append(changes, ControlEvent{When: when, Button: Button(b), Pressed: pressed})
+		}
+	}
+	for a := range t.state.Axes {
+		if value := t.state.Axes[a]; value != old.Axes[a] {
+			changes = append(changes, ControlEvent{When: when, IsAxis: true, Axis: Axis(a), Value: value})
+		}
+	}
+	return changes
+}
+
+// Apply processes an evdev event and returns the logical controls it
+// changed. After a SYN_DROPPED it discards events up to the next SYN_REPORT,
+// as the evdev protocol requires, and then reports Stale.
+func (t *GamepadTracker) Apply(ev Event) []ControlEvent {
+	if ev.Kind == EVSyn && ev.Code == SynDropped {
+		t.dropping = true
+		return nil
+	}
+	if t.dropping {
+		if ev.Kind == EVSyn && ev.Code == SynReport {
+			t.dropping, t.stale = false, true
+		}
+		return nil
+	}
+
+	var changes []ControlEvent
+	if dpad, changed, ok := t.dpad.Update(ev); ok {
+		if !changed {
+			return nil
+		}
+		for _, b := range []struct {
+			button  Button
+			pressed bool
+		}{
+			{ButtonDpadUp, dpad.Up},
+			{ButtonDpadDown, dpad.Down},
+			{ButtonDpadLeft, dpad.Left},
+			{ButtonDpadRight, dpad.Right},
+		} {
+			if change, ok := t.state.setButton(ev.When, b.button, b.pressed); ok {
+				changes = append(changes, change)
+			}
+		}
+		return changes
+	}
+
+	switch ev.Kind {
+	case EVKey:
+		b, ok := MapButton(t.Family, ev.Code)
+		// The DpadDecoder owns the D-pad; codes of another form, like extra
+		// trigger-happy buttons on a hat device, are not D-pad presses.
+		if !ok || b >= ButtonDpadUp && b <= ButtonDpadRight {
+			return nil
+		}
+		if change, ok := t.state.setButton(ev.When, b, ev.Value != KeyReleased); ok {
+			changes = append(changes, change)
+		}
+	case EVAbs:
+		a, ok := MapAxis(ev.Code)
+		if !ok || t.norm == nil {
+			return nil
+		}
+		// The kernel has already applied the axis fuzz.
+		axis := t.norm.Axis(ev.Code)
+		if axis == nil {
+			return nil
+		}
+		if change, ok := t.state.setAxis(ev.When, a, axis.Normalize(ev.Value)); ok {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// standardTriggerThreshold is the analog trigger value at which a standard
+// gamepad trigger button reads as pressed, as in Chromium.
+const standardTriggerThreshold = 30.0 / 255
+
+// StandardButtonCount and StandardAxisCount are the sizes of the W3C
+// "standard" gamepad layout.
+const (
+	StandardButtonCount = 17
+	StandardAxisCount   = 4
+)
+
+// StandardGamepad is a gamepad in the W3C Gamepad API "standard" mapping,
+// shaped like the JavaScript Gamepad object when encoded as JSON.
+type StandardGamepad struct {
+	ID        string `json:"id"`
+	Index     int    `json:"index"`
+	Connected bool   `json:"connected"`
+	// Timestamp is the time of the last update in milliseconds since the
+	// Unix epoch.
+	Timestamp float64                             `json:"timestamp"`
+	Mapping   string                              `json:"mapping"`
+	Axes      [StandardAxisCount]float32          `json:"axes"`
+	Buttons   [StandardButtonCount]StandardButton `json:"buttons"`
+}
+
+// StandardButton is a GamepadButton.
+type StandardButton struct {
+	Pressed bool    `json:"pressed"`
+	Touched bool    `json:"touched"`
+	Value   float32 `json:"value"`
+}
+
+// Standard converts the state to the W3C "standard" layout: buttons 0-16 in
+// Button order with the D-pad as buttons 12-15, analog trigger values on
+// buttons 6 and 7, and the stick axes as axes 0-3. Share and the paddles
+// have no standard position and are left out. The result is connected; set
+// Connected to false when the device goes away.
+func (s GamepadState) Standard(id string, index int) StandardGamepad {
+	g := StandardGamepad{
+		ID:        id,
+		Index:     index,
+		Connected: true,
+		Mapping:   "standard",
+	}
+	if !s.When.IsZero() {
+		g.Timestamp = float64(s.When.UnixNano()) / 1e6
+	}
+	for i := range g.Buttons {
+		pressed := s.Buttons[i]
+		value := float32(0)
+		if pressed {
+			value = 1
+		}
+		switch Button(i) {
+		case ButtonLeftTrigger, ButtonRightTrigger:
+			// Digital triggers (triggers_to_buttons) keep value 1.
+			axis := AxisLeftTrigger
+			if Button(i) == ButtonRightTrigger {
+				axis = AxisRightTrigger
+			}
+			if analog := s.Axes[axis]; analog > value {
+				value = analog
+			}
+			pressed = value >= standardTriggerThreshold
+		}
+		g.Buttons[i] = StandardButton{Pressed: pressed, Touched: pressed || value > 0, Value: value}
+	}
+	copy(g.Axes[:], s.Axes[:StandardAxisCount])
+	return g
+}
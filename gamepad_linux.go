@@ -0,0 +1,50 @@
+//go:build linux
+
+package xpad
+
+import "time"
+
+// GamepadTracker returns a tracker for the device's events, with the D-pad
+// form detected from its capabilities and the state seeded from the keys
+// and axes currently held.
+func (d *Device) GamepadTracker(family ControllerFamily) (*GamepadTracker, error) {
+	if d == nil || d.file == nil {
+		return nil, ErrClosed
+	}
+	caps, err := d.capabilities(EVAbs, EVKey)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := d.absInfos(caps)
+	if err != nil {
+		return nil, err
+	}
+	t := NewGamepadTracker(family, NewNormalizer(infos))
+	t.dpad.Form = DetectDpadForm(caps)
+	if _, err := d.SyncGamepadTracker(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SyncGamepadTracker reads the keys and axes currently held and applies them
+// to t with Sync. Call it when t reports Stale.
+func (d *Device) SyncGamepadTracker(t *GamepadTracker) ([]ControlEvent, error) {
+	keys, err := d.KeyState()
+	if err != nil {
+		return nil, err
+	}
+	caps, err := d.capabilities(EVAbs)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := d.absInfos(caps)
+	if err != nil {
+		return nil, err
+	}
+	axes := make(map[uint16]int32, len(infos))
+	for code, info := range infos {
+		axes[code] = info.Value
+	}
+	return t.Sync(time.Now(), keys, axes), nil
+}
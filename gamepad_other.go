@@ -0,0 +1,13 @@
+//go:build !linux
+
+package xpad
+
+// GamepadTracker is not supported on non-Linux platforms.
+func (d *Device) GamepadTracker(family ControllerFamily) (*GamepadTracker, error) {
+	return nil, ErrNotImplemented
+}
+
+// SyncGamepadTracker is not supported on non-Linux platforms.
+func (d *Device) SyncGamepadTracker(t *GamepadTracker) ([]ControlEvent, error) {
+	return nil, ErrNotImplemented
+}
@@ -0,0 +1,158 @@
+package xpad
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGamepadTracker(t *testing.T) {
+	norm := NewNormalizer(map[uint16]AbsInfo{
+		ABSX:  {Minimum: -32768, Maximum: 32767},
+		ABSZ:  {Maximum: 255},
+		ABSRZ: {Maximum: 255, Fuzz: 8},
+	})
+	tracker := NewGamepadTracker(FamilyXboxOne, norm)
+	events := []struct {
+		ev   Event
+		want int
+	}{
+		{Event{Kind: EVKey, Code: BTNA, Value: KeyPressed}, 1},
+		{Event{Kind: EVKey, Code: KeyRecord, Value: KeyPressed}, 1},
+		{Event{Kind: EVAbs, Code: ABSHat0X, Value: -1}, 1},
+		{Event{Kind: EVKey, Code: BTNTriggerHappy3, Value: KeyPressed}, 1},
+		{Event{Kind: EVAbs, Code: ABSHat0X, Value: -1}, 0},
+		{Event{Kind: EVAbs, Code: ABSX, Value: 32767}, 1},
+		{Event{Kind: EVAbs, Code: ABSZ, Value: 255}, 1},
+		{Event{Kind: EVAbs, Code: ABSRZ, Value: 20}, 1},
+		{Event{Kind: EVAbs, Code: ABSProfile, Value: 1}, 0},
+		{Event{Kind: EVSyn, Code: SynReport}, 0},
+	}
+	for _, e := range events {
+		if got := tracker.Apply(e.ev); len(got) != e.want {
+			t.Fatalf("Apply(%+v) = %+v, want %d changes", e.ev, got, e.want)
+		}
+	}
+	state := tracker.State()
+	if !state.Buttons[ButtonA] || !state.Buttons[ButtonShare] || !state.Buttons[ButtonDpadLeft] || !state.Buttons[ButtonDpadUp] {
+		t.Fatalf("Buttons = %v", state.Buttons)
+	}
+	if state.Axes[AxisLeftX] != 1 || state.Axes[AxisLeftTrigger] != 1 {
+		t.Fatalf("Axes = %v", state.Axes)
+	}
+
+	// Small evdev changes are kept even if the normalizer defuzzes.
+	norm.Defuzz = true
+	if got := tracker.Apply(Event{Kind: EVAbs, Code: ABSRZ, Value: 21}); len(got) != 1 {
+		t.Fatalf("Apply(ABSRZ 21) = %+v, want 1 change", got)
+	}
+
+	// A digital trigger, as with triggers_to_buttons.
+	tracker.Apply(Event{Kind: EVKey, Code: BTNTR2, Value: KeyPressed})
+	g := tracker.State().Standard("Xbox One Controller", 0)
+	if b := g.Buttons[ButtonRightTrigger]; !b.Pressed || b.Value != 1 {
+		t.Fatalf("Buttons[7] = %+v, want pressed with value 1", b)
+	}
+}
+
+func TestGamepadStateStandard(t *testing.T) {
+	var s GamepadState
+	s.When = time.UnixMilli(1500)
+	s.Buttons[ButtonB] = true
+	s.Buttons[ButtonDpadRight] = true
+	s.Buttons[ButtonGuide] = true
+	s.Buttons[ButtonShare] = true
+	s.Axes[AxisLeftY] = -0.5
+	s.Axes[AxisRightX] = 0.25
+	s.Axes[AxisLeftTrigger] = 0.1
+	s.Axes[AxisRightTrigger] = 0.75
+
+	g := s.Standard("pad", 2)
+	checks := []struct {
+		index int
+		want  StandardButton
+	}{
+		{0, StandardButton{}},
+		{1, StandardButton{Pressed: true, Touched: true, Value: 1}},
+		{6, StandardButton{Touched: true, Value: 0.1}},
+		{7, StandardButton{Pressed: true, Touched: true, Value: 0.75}},
+		{15, StandardButton{Pressed: true, Touched: true, Value: 1}},
+		{16, StandardButton{Pressed: true, Touched: true, Value: 1}},
+	}
+	for _, c := range checks {
+		if g.Buttons[c.index] != c.want {
+			t.Fatalf("Buttons[%d] = %+v, want %+v", c.index, g.Buttons[c.index], c.want)
+		}
+	}
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	for _, want := range []string{
+		`"id":"pad"`, `"index":2`, `"connected":true`, `"timestamp":1500`,
+		`"mapping":"standard"`, `"axes":[0,-0.5,0.25,0]`,
+		`"buttons":[{"pressed":false,"touched":false,"value":0},{"pressed":true,"touched":true,"value":1}`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("JSON = %s, want it to contain %s", data, want)
+		}
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if n := len(decoded["buttons"].([]any)); n != StandardButtonCount {
+		t.Fatalf("len(buttons) = %d, want %d", n, StandardButtonCount)
+	}
+}
+
+func TestGamepadTrackerSync(t *testing.T) {
+	norm := NewNormalizer(map[uint16]AbsInfo{
+		ABSX: {Minimum: -32768, Maximum: 32767},
+		ABSZ: {Maximum: 255},
+	})
+	tracker := NewGamepadTracker(FamilyXbox360, norm)
+	tracker.dpad.Form = DpadFormHat
+
+	// A button, a stick and the D-pad held when the stream starts.
+	keys := make([]byte, bitsetBytes(KeyMax))
+	for _, code := range []uint16{BTNA, BTNTriggerHappy1} {
+		keys[code/8] |= 1 << (code % 8)
+	}
+	when := time.Unix(1, 0)
+	changes := tracker.Sync(when, keys, map[uint16]int32{ABSX: 32767, ABSZ: 0, ABSHat0Y: -1})
+	if len(changes) != 3 {
+		t.Fatalf("Sync() = %+v, want A, D-pad Up and Left Stick X", changes)
+	}
+	state := tracker.State()
+	// BTNTriggerHappy1 is not part of a hat D-pad.
+	if !state.Buttons[ButtonA] || !state.Buttons[ButtonDpadUp] || state.Buttons[ButtonDpadLeft] || state.Axes[AxisLeftX] != 1 {
+		t.Fatalf("State() = %+v", state)
+	}
+	if !state.When.Equal(when) {
+		t.Fatalf("When = %v, want %v", state.When, when)
+	}
+
+	// Events between SYN_DROPPED and the next SYN_REPORT are discarded.
+	for _, ev := range []Event{
+		{Kind: EVSyn, Code: SynDropped},
+		{Kind: EVKey, Code: BTNB, Value: KeyPressed},
+		{Kind: EVSyn, Code: SynReport},
+	} {
+		if got := tracker.Apply(ev); got != nil {
+			t.Fatalf("Apply(%+v) after SYN_DROPPED = %+v, want nil", ev, got)
+		}
+	}
+	if !tracker.Stale() {
+		t.Fatalf("Stale() = false after SYN_DROPPED")
+	}
+	changes = tracker.Sync(when, make([]byte, bitsetBytes(KeyMax)), map[uint16]int32{ABSX: 0, ABSZ: 0, ABSHat0Y: 0})
+	if tracker.Stale() || len(changes) != 3 {
+		t.Fatalf("Sync() = %+v, Stale() = %v, want A, D-pad Up and Left Stick X released", changes, tracker.Stale())
+	}
+	if got := tracker.Apply(Event{Kind: EVKey, Code: BTNB, Value: KeyPressed}); len(got) != 1 {
+		t.Fatalf("Apply(BTNB) after Sync = %+v, want 1 change", got)
+	}
+}
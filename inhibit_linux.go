@@ -0,0 +1,47 @@
+//go:build linux
+
+package xpad
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Inhibited reports whether the input device is inhibited. An inhibited device
+// stops generating events for every reader.
+func (d DeviceInfo) Inhibited() (bool, error) {
+	path, err := d.inhibitedPath()
+	if err != nil {
+		return false, err
+	}
+	value, err := readBoolParam(path)
+	if os.IsNotExist(err) {
+		return false, ErrInhibitUnsupported
+	}
+	return value, err
+}
+
+// SetInhibited inhibits or uninhibits the input device.
+//
+// This typically requires elevated permissions.
+func (d DeviceInfo) SetInhibited(inhibit bool) error {
+	path, err := d.inhibitedPath()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return ErrInhibitUnsupported
+	}
+	if err := writeBoolParam(path, inhibit); err != nil {
+		return fmt.Errorf("xpad: set inhibited on %s: %w", path, err)
+	}
+	return nil
+}
+
+func (d DeviceInfo) inhibitedPath() (string, error) {
+	if d.SysfsPath == "" {
+		return "", ErrNotFound
+	}
+	return filepath.Join(d.SysfsPath, "device", "inhibited"), nil
+}
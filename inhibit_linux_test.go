@@ -0,0 +1,44 @@
+//go:build linux
+
+package xpad
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDeviceInfoInhibited(t *testing.T) {
+	sysfs := t.TempDir()
+	info := DeviceInfo{SysfsPath: sysfs}
+
+	if _, err := info.Inhibited(); !errors.Is(err, ErrInhibitUnsupported) {
+		t.Fatalf("Inhibited() without attribute error = %v, want ErrInhibitUnsupported", err)
+	}
+	if err := info.SetInhibited(true); !errors.Is(err, ErrInhibitUnsupported) {
+		t.Fatalf("SetInhibited() without attribute error = %v, want ErrInhibitUnsupported", err)
+	}
+
+	attr := filepath.Join(sysfs, "device", "inhibited")
+	if err := os.MkdirAll(filepath.Dir(attr), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(attr, []byte("0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, err := info.Inhibited(); err != nil || got {
+		t.Fatalf("Inhibited() = %v, %v, want false, nil", got, err)
+	}
+	if err := info.SetInhibited(true); err != nil {
+		t.Fatalf("SetInhibited(true) error: %v", err)
+	}
+	if got, err := info.Inhibited(); err != nil || !got {
+		t.Fatalf("Inhibited() = %v, %v, want true, nil", got, err)
+	}
+
+	if _, err := (DeviceInfo{}).Inhibited(); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Inhibited() without SysfsPath error = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package xpad
+
+// Inhibited is not supported on non-Linux platforms.
+func (d DeviceInfo) Inhibited() (bool, error) { return false, ErrNotImplemented }
+
+// SetInhibited is not supported on non-Linux platforms.
+func (d DeviceInfo) SetInhibited(inhibit bool) error { return ErrNotImplemented }
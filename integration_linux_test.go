@@ -441,6 +441,8 @@ type evdevStep struct {
 	KeyCodes []uint16
 	AbsCode  uint16
 	AbsMatch func(int32) bool
+	// Dpad matches a D-pad direction in any of the forms DpadDecoder accepts.
+	Dpad DpadDirection
 }
 
 type buttonStep struct {
@@ -481,30 +483,10 @@ func xbox360Profile() controllerProfile {
 			{Name: "Guide", KeyCodes: []uint16{BTNMode}},
 			{Name: "Left Stick Click", KeyCodes: []uint16{BTNThumbL}},
 			{Name: "Right Stick Click", KeyCodes: []uint16{BTNThumbR}},
-			{
-				Name:     "D-pad Up",
-				KeyCodes: []uint16{BTNDPadUp},
-				AbsCode:  ABSHat0Y,
-				AbsMatch: func(v int32) bool { return v < 0 },
-			},
-			{
-				Name:     "D-pad Down",
-				KeyCodes: []uint16{BTNDPadDown},
-				AbsCode:  ABSHat0Y,
-				AbsMatch: func(v int32) bool { return v > 0 },
-			},
-			{
-				Name:     "D-pad Left",
-				KeyCodes: []uint16{BTNDPadLeft},
-				AbsCode:  ABSHat0X,
-				AbsMatch: func(v int32) bool { return v < 0 },
-			},
-			{
-				Name:     "D-pad Right",
-				KeyCodes: []uint16{BTNDPadRight},
-				AbsCode:  ABSHat0X,
-				AbsMatch: func(v int32) bool { return v > 0 },
-			},
+			{Name: "D-pad Up", Dpad: DpadUp},
+			{Name: "D-pad Down", Dpad: DpadDown},
+			{Name: "D-pad Left", Dpad: DpadLeft},
+			{Name: "D-pad Right", Dpad: DpadRight},
 			{
 				Name:     "Left Trigger",
 				KeyCodes: []uint16{BTNTL2},
@@ -535,6 +517,7 @@ func xbox360Profile() controllerProfile {
 }
 
 func waitForEvdevStep(dev *Device, step evdevStep, timeout time.Duration) error {
+	var dpad DpadDecoder
 	deadline := time.Now().Add(timeout)
 	for time.Now().Before(deadline) {
 		ev, err := dev.ReadEvent(500 * time.Millisecond)
@@ -544,6 +527,12 @@ func waitForEvdevStep(dev *Device, step evdevStep, timeout time.Duration) error
 			}
 			return err
 		}
+		if step.Dpad != DpadCentered {
+			if state, _, ok := dpad.Update(ev); ok && state.Direction() == step.Dpad {
+				return nil
+			}
+			continue
+		}
 		if matchEvdevStep(step, ev) {
 			return nil
 		}
@@ -62,18 +62,37 @@ const (
 
 // Joystick represents an open /dev/input/js* device.
 type Joystick struct {
-	Path     string
-	file     *os.File
-	readOnly bool
+	Path       string
+	file       *os.File
+	readOnly   bool
+	downgraded bool
 }
 
 // OpenJoystick opens a joystick device by path.
 func OpenJoystick(path string) (*Joystick, error) {
-	file, readOnly, err := openReadWriteOrReadOnly(path)
+	return OpenJoystickWithOptions(path, Options{})
+}
+
+// OpenJoystickWithOptions opens a joystick device by path using opts.
+//
+// Grab, mask and clock settings only apply to evdev nodes and are rejected.
+func OpenJoystickWithOptions(path string, opts Options) (*Joystick, error) {
+	if opts.hasEvdevSettings() {
+		return nil, fmt.Errorf("xpad: grab, mask and clock options require an evdev device")
+	}
+	file, readOnly, downgraded, err := openWithOptions(path, opts)
 	if err != nil {
 		return nil, err
 	}
-	return &Joystick{Path: path, file: file, readOnly: readOnly}, nil
+	j := &Joystick{Path: path, file: file, readOnly: readOnly, downgraded: downgraded}
+	if opts.KeepOnExec {
+		fd, _ := j.FD()
+		if err := clearCloseOnExec(fd); err != nil {
+			j.Close()
+			return nil, err
+		}
+	}
+	return j, nil
 }
 
 // Close closes the joystick device.
@@ -84,6 +103,7 @@ func (j *Joystick) Close() error {
 	err := j.file.Close()
 	j.file = nil
 	j.readOnly = false
+	j.downgraded = false
 	return err
 }
 
@@ -103,6 +123,15 @@ func (j *Joystick) ReadOnly() bool {
 	return j.readOnly
 }
 
+// Downgraded reports whether a read-write open failed and the handle fell
+// back to read-only.
+func (j *Joystick) Downgraded() bool {
+	if j == nil {
+		return false
+	}
+	return j.downgraded
+}
+
 // Version returns the joystick driver version.
 func (j *Joystick) Version() (uint32, error) {
 	if j == nil || j.file == nil {
@@ -280,6 +309,15 @@ func OpenJoystickDevice(info DeviceInfo) (*Joystick, error) {
 	return OpenJoystick(info.JoystickPath)
 }
 
+// OpenJoystickDeviceWithOptions opens the joystick for a discovered device
+// using opts.
+func OpenJoystickDeviceWithOptions(info DeviceInfo, opts Options) (*Joystick, error) {
+	if info.JoystickPath == "" {
+		return nil, ErrNotFound
+	}
+	return OpenJoystickWithOptions(info.JoystickPath, opts)
+}
+
 // OpenJoystick opens the joystick for a discovered device.
 func (d DeviceInfo) OpenJoystick() (*Joystick, error) {
 	return OpenJoystickDevice(d)
@@ -10,9 +10,19 @@ type Joystick struct{}
 // OpenJoystick is not supported on non-Linux platforms.
 func OpenJoystick(path string) (*Joystick, error) { return nil, ErrNotImplemented }
 
+// OpenJoystickWithOptions is not supported on non-Linux platforms.
+func OpenJoystickWithOptions(path string, opts Options) (*Joystick, error) {
+	return nil, ErrNotImplemented
+}
+
 // OpenJoystickDevice is not supported on non-Linux platforms.
 func OpenJoystickDevice(info DeviceInfo) (*Joystick, error) { return nil, ErrNotImplemented }
 
+// OpenJoystickDeviceWithOptions is not supported on non-Linux platforms.
+func OpenJoystickDeviceWithOptions(info DeviceInfo, opts Options) (*Joystick, error) {
+	return nil, ErrNotImplemented
+}
+
 // OpenJoystick is not supported on non-Linux platforms.
 func (d DeviceInfo) OpenJoystick() (*Joystick, error) { return nil, ErrNotImplemented }
 
@@ -25,6 +35,9 @@ func (j *Joystick) FD() (uintptr, error) { return 0, ErrNotImplemented }
 // ReadOnly is not supported on non-Linux platforms.
 func (j *Joystick) ReadOnly() bool { return true }
 
+// Downgraded is not supported on non-Linux platforms.
+func (j *Joystick) Downgraded() bool { return false }
+
 // Version is not supported on non-Linux platforms.
 func (j *Joystick) Version() (uint32, error) { return 0, ErrNotImplemented }
 
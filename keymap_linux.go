@@ -0,0 +1,153 @@
+//go:build linux
+
+package xpad
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+
+	"github.com/roryl23/xpad-go/internal/ioctl"
+)
+
+const inputKeymapByIndex = 1 << 0
+
+// inputKeymapEntry mirrors struct input_keymap_entry.
+type inputKeymapEntry struct {
+	Flags    uint8
+	Len      uint8
+	Index    uint16
+	Keycode  uint32
+	Scancode [MaxScancodeLen]uint8
+}
+
+func evioCGKEYCODEV2() uint {
+	return ioctl.IOR(evdevIOCBase, 0x04, ioctl.Size(inputKeymapEntry{}))
+}
+
+func evioCSKEYCODEV2() uint {
+	return ioctl.IOW(evdevIOCBase, 0x04, ioctl.Size(inputKeymapEntry{}))
+}
+
+// KeymapEntryByIndex returns the keymap entry stored at index.
+func (d *Device) KeymapEntryByIndex(index uint16) (KeymapEntry, error) {
+	raw := inputKeymapEntry{Flags: inputKeymapByIndex, Index: index}
+	if err := d.getKeymapEntry(&raw); err != nil {
+		return KeymapEntry{}, err
+	}
+	return raw.entry(), nil
+}
+
+// KeymapEntryByScancode returns the keymap entry for scancode.
+func (d *Device) KeymapEntryByScancode(scancode []byte) (KeymapEntry, error) {
+	raw, err := newInputKeymapEntry(KeymapEntry{Scancode: scancode})
+	if err != nil {
+		return KeymapEntry{}, err
+	}
+	if err := d.getKeymapEntry(&raw); err != nil {
+		return KeymapEntry{}, err
+	}
+	return raw.entry(), nil
+}
+
+// SetKeycodeByIndex remaps the keymap entry at index to keycode.
+func (d *Device) SetKeycodeByIndex(index uint16, keycode uint32) error {
+	raw := inputKeymapEntry{Flags: inputKeymapByIndex, Index: index, Keycode: keycode}
+	return d.setKeymapEntry(&raw)
+}
+
+// SetKeycodeByScancode remaps scancode to keycode.
+func (d *Device) SetKeycodeByScancode(scancode []byte, keycode uint32) error {
+	raw, err := newInputKeymapEntry(KeymapEntry{Keycode: keycode, Scancode: scancode})
+	if err != nil {
+		return err
+	}
+	return d.setKeymapEntry(&raw)
+}
+
+// Keymap returns every entry of the device keymap in index order.
+//
+// Devices without a keymap (including most xpad pads) return an empty slice.
+func (d *Device) Keymap() ([]KeymapEntry, error) {
+	var entries []KeymapEntry
+	for index := 0; index <= 0xffff; index++ {
+		entry, err := d.KeymapEntryByIndex(uint16(index))
+		if err != nil {
+			if errors.Is(err, syscall.EINVAL) {
+				break
+			}
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// RestoreKeymap writes entries back by index, typically from Keymap.
+func (d *Device) RestoreKeymap(entries []KeymapEntry) error {
+	for _, entry := range entries {
+		if err := d.SetKeycodeByIndex(entry.Index, entry.Keycode); err != nil {
+			return fmt.Errorf("xpad: restore keymap index %d: %w", entry.Index, err)
+		}
+	}
+	return nil
+}
+
+// SaveKeymap snapshots the keymap and returns a function that restores it,
+// so remapping can be scoped to a session:
+//
+//	restore, err := dev.SaveKeymap()
+//	if err != nil { ... }
+//	defer restore()
+func (d *Device) SaveKeymap() (func() error, error) {
+	entries, err := d.Keymap()
+	if err != nil {
+		return nil, err
+	}
+	return func() error {
+		return d.RestoreKeymap(entries)
+	}, nil
+}
+
+func (d *Device) getKeymapEntry(raw *inputKeymapEntry) error {
+	if d == nil || d.file == nil {
+		return ErrClosed
+	}
+	fd, _ := d.FD()
+	return ioctl.CallPtr(fd, evioCGKEYCODEV2(), unsafe.Pointer(raw))
+}
+
+func (d *Device) setKeymapEntry(raw *inputKeymapEntry) error {
+	if d == nil || d.file == nil {
+		return ErrClosed
+	}
+	if d.readOnly {
+		return ErrReadOnly
+	}
+	fd, _ := d.FD()
+	return ioctl.CallPtr(fd, evioCSKEYCODEV2(), unsafe.Pointer(raw))
+}
+
+func newInputKeymapEntry(entry KeymapEntry) (inputKeymapEntry, error) {
+	if len(entry.Scancode) == 0 || len(entry.Scancode) > MaxScancodeLen {
+		return inputKeymapEntry{}, fmt.Errorf("xpad: scancode length %d, want 1-%d", len(entry.Scancode), MaxScancodeLen)
+	}
+	raw := inputKeymapEntry{
+		Len:     uint8(len(entry.Scancode)),
+		Index:   entry.Index,
+		Keycode: entry.Keycode,
+	}
+	copy(raw.Scancode[:], entry.Scancode)
+	return raw, nil
+}
+
+func (e inputKeymapEntry) entry() KeymapEntry {
+	length := int(e.Len)
+	if length > MaxScancodeLen {
+		length = MaxScancodeLen
+	}
+	scancode := make([]byte, length)
+	copy(scancode, e.Scancode[:length])
+	return KeymapEntry{Index: e.Index, Keycode: e.Keycode, Scancode: scancode}
+}
@@ -0,0 +1,38 @@
+//go:build linux
+
+package xpad
+
+import (
+	"bytes"
+	"testing"
+	"unsafe"
+)
+
+func TestInputKeymapEntryLayout(t *testing.T) {
+	if got := unsafe.Sizeof(inputKeymapEntry{}); got != 40 {
+		t.Fatalf("sizeof(inputKeymapEntry) = %d, want 40", got)
+	}
+}
+
+func TestInputKeymapEntryRoundTrip(t *testing.T) {
+	scancode := Scancode32(0x90001)
+	raw, err := newInputKeymapEntry(KeymapEntry{Index: 3, Keycode: BTNA, Scancode: scancode})
+	if err != nil {
+		t.Fatalf("newInputKeymapEntry() error: %v", err)
+	}
+	if raw.Len != 4 || raw.Flags != 0 {
+		t.Fatalf("raw entry len=%d flags=%d, want len=4 flags=0", raw.Len, raw.Flags)
+	}
+
+	entry := raw.entry()
+	if entry.Index != 3 || entry.Keycode != BTNA || !bytes.Equal(entry.Scancode, scancode) {
+		t.Fatalf("entry() = %+v, want index 3 keycode %#x scancode %x", entry, BTNA, scancode)
+	}
+
+	if _, err := newInputKeymapEntry(KeymapEntry{}); err == nil {
+		t.Fatalf("newInputKeymapEntry() with empty scancode should fail")
+	}
+	if _, err := newInputKeymapEntry(KeymapEntry{Scancode: make([]byte, MaxScancodeLen+1)}); err == nil {
+		t.Fatalf("newInputKeymapEntry() with oversized scancode should fail")
+	}
+}
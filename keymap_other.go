@@ -0,0 +1,30 @@
+//go:build !linux
+
+package xpad
+
+// KeymapEntryByIndex is not supported on non-Linux platforms.
+func (d *Device) KeymapEntryByIndex(index uint16) (KeymapEntry, error) {
+	return KeymapEntry{}, ErrNotImplemented
+}
+
+// KeymapEntryByScancode is not supported on non-Linux platforms.
+func (d *Device) KeymapEntryByScancode(scancode []byte) (KeymapEntry, error) {
+	return KeymapEntry{}, ErrNotImplemented
+}
+
+// SetKeycodeByIndex is not supported on non-Linux platforms.
+func (d *Device) SetKeycodeByIndex(index uint16, keycode uint32) error { return ErrNotImplemented }
+
+// SetKeycodeByScancode is not supported on non-Linux platforms.
+func (d *Device) SetKeycodeByScancode(scancode []byte, keycode uint32) error {
+	return ErrNotImplemented
+}
+
+// Keymap is not supported on non-Linux platforms.
+func (d *Device) Keymap() ([]KeymapEntry, error) { return nil, ErrNotImplemented }
+
+// RestoreKeymap is not supported on non-Linux platforms.
+func (d *Device) RestoreKeymap(entries []KeymapEntry) error { return ErrNotImplemented }
+
+// SaveKeymap is not supported on non-Linux platforms.
+func (d *Device) SaveKeymap() (func() error, error) { return nil, ErrNotImplemented }
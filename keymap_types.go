@@ -0,0 +1,24 @@
+package xpad
+
+import "encoding/binary"
+
+// MaxScancodeLen is the largest scancode accepted by the kernel keymap ioctls.
+const MaxScancodeLen = 32
+
+// KeymapEntry mirrors struct input_keymap_entry.
+type KeymapEntry struct {
+	// Index is the position of the entry in the device keymap.
+	Index uint16
+	// Keycode is the KEY_* or BTN_* code reported for the scancode.
+	Keycode uint32
+	// Scancode is the raw, driver-specific scancode (at most MaxScancodeLen bytes).
+	Scancode []byte
+}
+
+// Scancode32 encodes a numeric scancode in the little-endian form most
+// drivers (including HID) use.
+func Scancode32(value uint32) []byte {
+	buf := make([]byte, 4)
+	binary.LittleEndian.PutUint32(buf, value)
+	return buf
+}
@@ -0,0 +1,136 @@
+package xpad
+
+import "strings"
+
+// ControllerFamily identifies the controller generation, mirroring the xpad
+// driver's XTYPE_* values.
+type ControllerFamily uint8
+
+const (
+	FamilyUnknown ControllerFamily = iota
+	// FamilyXbox is the original Xbox controller (XTYPE_XBOX).
+	FamilyXbox
+	// FamilyXbox360 is a wired Xbox 360 controller (XTYPE_XBOX360).
+	FamilyXbox360
+	// FamilyXbox360Wireless is a pad behind the Xbox 360 wireless receiver
+	// (XTYPE_XBOX360W).
+	FamilyXbox360Wireless
+	// FamilyXboxOne is an Xbox One controller (XTYPE_XBOXONE).
+	FamilyXboxOne
+	// FamilyXboxSeries is an Xbox Series X|S controller. The driver treats it
+	// as XTYPE_XBOXONE; it adds a Share button.
+	FamilyXboxSeries
+)
+
+// String returns a human-readable family name.
+func (f ControllerFamily) String() string {
+	switch f {
+	case FamilyXbox:
+		return "Xbox"
+	case FamilyXbox360:
+		return "Xbox 360"
+	case FamilyXbox360Wireless:
+		return "Xbox 360 Wireless"
+	case FamilyXboxOne:
+		return "Xbox One"
+	case FamilyXboxSeries:
+		return "Xbox Series"
+	default:
+		return "Unknown"
+	}
+}
+
+// DeviceQuirk mirrors the xpad driver's per-device MAP_* flags.
+type DeviceQuirk uint8
+
+const (
+	// QuirkDpadToButtons reports the D-pad as buttons (MAP_DPAD_TO_BUTTONS).
+	QuirkDpadToButtons DeviceQuirk = 1 << iota
+	// QuirkTriggersToButtons reports triggers as buttons (MAP_TRIGGERS_TO_BUTTONS).
+	QuirkTriggersToButtons
+	// QuirkSticksToNull ignores the analog sticks (MAP_STICKS_TO_NULL).
+	QuirkSticksToNull
+	// QuirkShareButton maps the Share button (MAP_SELECT_BUTTON/MAP_SHARE_BUTTON).
+	QuirkShareButton
+	// QuirkPaddles maps the Elite paddles (MAP_PADDLES).
+	QuirkPaddles
+	// QuirkProfileButton maps the profile switch (MAP_PROFILE_BUTTON).
+	QuirkProfileButton
+)
+
+// QuirkDancepad is the driver's DANCEPAD_MAP_CONFIG.
+const QuirkDancepad = QuirkDpadToButtons | QuirkTriggersToButtons | QuirkSticksToNull
+
+// Has reports whether every flag in other is set.
+func (q DeviceQuirk) Has(other DeviceQuirk) bool {
+	return q&other == other
+}
+
+// KnownDevice is an entry of the xpad device table.
+type KnownDevice struct {
+	VendorID  uint16
+	ProductID uint16
+	Name      string
+	Family    ControllerFamily
+	Quirks    DeviceQuirk
+}
+
+var knownDeviceIndex = func() map[uint32]int {
+	index := make(map[uint32]int, len(knownDevices))
+	for i, dev := range knownDevices {
+		index[uint32(dev.VendorID)<<16|uint32(dev.ProductID)] = i
+	}
+	return index
+}()
+
+// LookupKnownDevice returns the device table entry for a vendor/product pair.
+func LookupKnownDevice(vendor, product uint16) (KnownDevice, bool) {
+	i, ok := knownDeviceIndex[uint32(vendor)<<16|uint32(product)]
+	if !ok {
+		return KnownDevice{}, false
+	}
+	return knownDevices[i], true
+}
+
+// KnownDevices returns a copy of the device table.
+func KnownDevices() []KnownDevice {
+	return append([]KnownDevice(nil), knownDevices...)
+}
+
+// KnownDevice returns the device table entry matching the device IDs.
+func (d DeviceInfo) KnownDevice() (KnownDevice, bool) {
+	if d.VendorID == 0 && d.ProductID == 0 {
+		return KnownDevice{}, false
+	}
+	return LookupKnownDevice(d.VendorID, d.ProductID)
+}
+
+// Family returns the controller family from the device table, falling back to
+// the device name for pads the driver claims by interface class.
+func (d DeviceInfo) Family() ControllerFamily {
+	if known, ok := d.KnownDevice(); ok {
+		return known.Family
+	}
+	if !d.IsXpad() {
+		return FamilyUnknown
+	}
+	name := strings.ToLower(d.Name)
+	switch {
+	case strings.Contains(name, "series"):
+		return FamilyXboxSeries
+	case strings.Contains(name, "one"):
+		return FamilyXboxOne
+	case strings.Contains(name, "360") && strings.Contains(name, "wireless"):
+		return FamilyXbox360Wireless
+	case strings.Contains(name, "360"):
+		return FamilyXbox360
+	default:
+		return FamilyUnknown
+	}
+}
+
+// Quirks returns the driver mapping flags from the device table.
+func (d DeviceInfo) Quirks() DeviceQuirk {
+	known, _ := d.KnownDevice()
+	return known.Quirks
+}
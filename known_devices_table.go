@@ -0,0 +1,249 @@
+package xpad
+
+// knownDevices mirrors the xpad_device[] table in drivers/input/joystick/xpad.c.
+// Keep entries sorted by vendor and product ID.
+var knownDevices = []KnownDevice{
+	{VendorID: 0x0079, ProductID: 0x18d4, Name: "GPD Win 2 X-Box Controller", Family: FamilyXbox360},
+	{VendorID: 0x03eb, ProductID: 0xff01, Name: "Wooting One (Legacy)", Family: FamilyXbox360},
+	{VendorID: 0x03eb, ProductID: 0xff02, Name: "Wooting Two (Legacy)", Family: FamilyXbox360},
+	{VendorID: 0x03f0, ProductID: 0x038d, Name: "HyperX Clutch", Family: FamilyXbox360},
+	{VendorID: 0x03f0, ProductID: 0x048d, Name: "HyperX Clutch", Family: FamilyXbox360},
+	{VendorID: 0x03f0, ProductID: 0x0495, Name: "HyperX Clutch Gladiate", Family: FamilyXboxOne},
+	{VendorID: 0x044f, ProductID: 0x0f00, Name: "Thrustmaster Wheel", Family: FamilyXbox},
+	{VendorID: 0x044f, ProductID: 0x0f03, Name: "Thrustmaster Wheel", Family: FamilyXbox},
+	{VendorID: 0x044f, ProductID: 0x0f07, Name: "Thrustmaster, Inc. Controller", Family: FamilyXbox},
+	{VendorID: 0x044f, ProductID: 0x0f10, Name: "Thrustmaster Modena GT Wheel", Family: FamilyXbox},
+	{VendorID: 0x044f, ProductID: 0xb326, Name: "Thrustmaster Gamepad GP XID", Family: FamilyXbox360},
+	{VendorID: 0x045e, ProductID: 0x0202, Name: "Microsoft X-Box pad v1 (US)", Family: FamilyXbox},
+	{VendorID: 0x045e, ProductID: 0x0285, Name: "Microsoft X-Box pad (Japan)", Family: FamilyXbox},
+	{VendorID: 0x045e, ProductID: 0x0287, Name: "Microsoft Xbox Controller S", Family: FamilyXbox},
+	{VendorID: 0x045e, ProductID: 0x0288, Name: "Microsoft Xbox Controller S v2", Family: FamilyXbox},
+	{VendorID: 0x045e, ProductID: 0x0289, Name: "Microsoft X-Box pad v2 (US)", Family: FamilyXbox},
+	{VendorID: 0x045e, ProductID: 0x028e, Name: "Microsoft X-Box 360 pad", Family: FamilyXbox360},
+	{VendorID: 0x045e, ProductID: 0x028f, Name: "Microsoft X-Box 360 pad v2", Family: FamilyXbox360},
+	{VendorID: 0x045e, ProductID: 0x0291, Name: "Xbox 360 Wireless Receiver (XBOX)", Family: FamilyXbox360Wireless, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x045e, ProductID: 0x02a9, Name: "Xbox 360 Wireless Receiver (Unofficial)", Family: FamilyXbox360Wireless, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x045e, ProductID: 0x02d1, Name: "Microsoft X-Box One pad", Family: FamilyXboxOne},
+	{VendorID: 0x045e, ProductID: 0x02dd, Name: "Microsoft X-Box One pad (Firmware 2015)", Family: FamilyXboxOne},
+	{VendorID: 0x045e, ProductID: 0x02e3, Name: "Microsoft X-Box One Elite pad", Family: FamilyXboxOne, Quirks: QuirkPaddles},
+	{VendorID: 0x045e, ProductID: 0x02ea, Name: "Microsoft X-Box One S pad", Family: FamilyXboxOne},
+	{VendorID: 0x045e, ProductID: 0x0719, Name: "Xbox 360 Wireless Receiver", Family: FamilyXbox360Wireless, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x045e, ProductID: 0x0b00, Name: "Microsoft X-Box One Elite 2 pad", Family: FamilyXboxOne, Quirks: QuirkPaddles},
+	{VendorID: 0x045e, ProductID: 0x0b0a, Name: "Microsoft X-Box Adaptive Controller", Family: FamilyXboxOne, Quirks: QuirkProfileButton},
+	{VendorID: 0x045e, ProductID: 0x0b12, Name: "Microsoft Xbox Series S|X Controller", Family: FamilyXboxSeries, Quirks: QuirkShareButton},
+	{VendorID: 0x046d, ProductID: 0xc21d, Name: "Logitech Gamepad F310", Family: FamilyXbox360},
+	{VendorID: 0x046d, ProductID: 0xc21e, Name: "Logitech Gamepad F510", Family: FamilyXbox360},
+	{VendorID: 0x046d, ProductID: 0xc21f, Name: "Logitech Gamepad F710", Family: FamilyXbox360},
+	{VendorID: 0x046d, ProductID: 0xc242, Name: "Logitech Chillstream Controller", Family: FamilyXbox360},
+	{VendorID: 0x046d, ProductID: 0xca84, Name: "Logitech Xbox Cordless Controller", Family: FamilyXbox},
+	{VendorID: 0x046d, ProductID: 0xca88, Name: "Logitech Compact Controller for Xbox", Family: FamilyXbox},
+	{VendorID: 0x046d, ProductID: 0xca8a, Name: "Logitech Precision Vibration Feedback Wheel", Family: FamilyXbox},
+	{VendorID: 0x046d, ProductID: 0xcaa3, Name: "Logitech DriveFx Racing Wheel", Family: FamilyXbox360},
+	{VendorID: 0x056e, ProductID: 0x2004, Name: "Elecom JC-U3613M", Family: FamilyXbox360},
+	{VendorID: 0x05fd, ProductID: 0x1007, Name: "Mad Catz Controller (unverified)", Family: FamilyXbox},
+	{VendorID: 0x05fd, ProductID: 0x107a, Name: "InterAct 'PowerPad Pro' X-Box pad (Germany)", Family: FamilyXbox},
+	{VendorID: 0x05fe, ProductID: 0x3030, Name: "Chic Controller", Family: FamilyXbox},
+	{VendorID: 0x05fe, ProductID: 0x3031, Name: "Chic Controller", Family: FamilyXbox},
+	{VendorID: 0x062a, ProductID: 0x0020, Name: "Logic3 Xbox GamePad", Family: FamilyXbox},
+	{VendorID: 0x062a, ProductID: 0x0033, Name: "Competition Pro Steering Wheel", Family: FamilyXbox},
+	{VendorID: 0x06a3, ProductID: 0x0200, Name: "Saitek Racing Wheel", Family: FamilyXbox},
+	{VendorID: 0x06a3, ProductID: 0x0201, Name: "Saitek Adrenalin", Family: FamilyXbox},
+	{VendorID: 0x06a3, ProductID: 0xf51a, Name: "Saitek P3600", Family: FamilyXbox360},
+	{VendorID: 0x0738, ProductID: 0x4503, Name: "Mad Catz Racing Wheel", Family: FamilyXboxOne},
+	{VendorID: 0x0738, ProductID: 0x4506, Name: "Mad Catz 4506 Wireless Controller", Family: FamilyXbox},
+	{VendorID: 0x0738, ProductID: 0x4516, Name: "Mad Catz Control Pad", Family: FamilyXbox},
+	{VendorID: 0x0738, ProductID: 0x4520, Name: "Mad Catz Control Pad Pro", Family: FamilyXbox},
+	{VendorID: 0x0738, ProductID: 0x4522, Name: "Mad Catz LumiCON", Family: FamilyXbox},
+	{VendorID: 0x0738, ProductID: 0x4526, Name: "Mad Catz Control Pad Pro", Family: FamilyXbox},
+	{VendorID: 0x0738, ProductID: 0x4530, Name: "Mad Catz Universal MC2 Racing Wheel and Pedals", Family: FamilyXbox},
+	{VendorID: 0x0738, ProductID: 0x4536, Name: "Mad Catz MicroCON", Family: FamilyXbox},
+	{VendorID: 0x0738, ProductID: 0x4540, Name: "Mad Catz Beat Pad", Family: FamilyXbox, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x0738, ProductID: 0x4556, Name: "Mad Catz Lynx Wireless Controller", Family: FamilyXbox},
+	{VendorID: 0x0738, ProductID: 0x4586, Name: "Mad Catz MicroCon Wireless Controller", Family: FamilyXbox},
+	{VendorID: 0x0738, ProductID: 0x4588, Name: "Mad Catz Blaster", Family: FamilyXbox},
+	{VendorID: 0x0738, ProductID: 0x45ff, Name: "Mad Catz Beat Pad (w/ Handle)", Family: FamilyXbox, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x0738, ProductID: 0x4716, Name: "Mad Catz Wired Xbox 360 Controller", Family: FamilyXbox360},
+	{VendorID: 0x0738, ProductID: 0x4718, Name: "Mad Catz Street Fighter IV FightStick SE", Family: FamilyXbox360},
+	{VendorID: 0x0738, ProductID: 0x4726, Name: "Mad Catz Xbox 360 Controller", Family: FamilyXbox360},
+	{VendorID: 0x0738, ProductID: 0x4728, Name: "Mad Catz Street Fighter IV FightPad", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0738, ProductID: 0x4736, Name: "Mad Catz MicroCon Gamepad", Family: FamilyXbox360},
+	{VendorID: 0x0738, ProductID: 0x4738, Name: "Mad Catz Wired Xbox 360 Controller (SFIV)", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0738, ProductID: 0x4740, Name: "Mad Catz Beat Pad", Family: FamilyXbox360},
+	{VendorID: 0x0738, ProductID: 0x4743, Name: "Mad Catz Beat Pad Pro", Family: FamilyXbox, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x0738, ProductID: 0x4758, Name: "Mad Catz Arcade Game Stick", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0738, ProductID: 0x4a01, Name: "Mad Catz FightStick TE 2", Family: FamilyXboxOne, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0738, ProductID: 0x6040, Name: "Mad Catz Beat Pad Pro", Family: FamilyXbox, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x0738, ProductID: 0x9871, Name: "Mad Catz Portable Drum", Family: FamilyXbox360},
+	{VendorID: 0x0738, ProductID: 0xb726, Name: "Mad Catz Xbox controller - MW2", Family: FamilyXbox360},
+	{VendorID: 0x0738, ProductID: 0xb738, Name: "Mad Catz MVC2TE Stick 2", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0738, ProductID: 0xbeef, Name: "Mad Catz JOYTECH NEO SE Advanced GamePad", Family: FamilyXbox360},
+	{VendorID: 0x0738, ProductID: 0xcb02, Name: "Saitek Cyborg Rumble Pad - PC/Xbox 360", Family: FamilyXbox360},
+	{VendorID: 0x0738, ProductID: 0xcb03, Name: "Saitek P3200 Rumble Pad - PC/Xbox 360", Family: FamilyXbox360},
+	{VendorID: 0x0738, ProductID: 0xcb29, Name: "Saitek Aviator Stick AV8R02", Family: FamilyXbox360},
+	{VendorID: 0x0738, ProductID: 0xf738, Name: "Super SFIV FightStick TE S", Family: FamilyXbox360},
+	{VendorID: 0x07ff, ProductID: 0xffff, Name: "Mad Catz GamePad", Family: FamilyXbox360},
+	{VendorID: 0x0c12, ProductID: 0x0005, Name: "Intec wireless", Family: FamilyXbox},
+	{VendorID: 0x0c12, ProductID: 0x8801, Name: "Nyko Xbox Controller", Family: FamilyXbox},
+	{VendorID: 0x0c12, ProductID: 0x8802, Name: "Zeroplus Xbox Controller", Family: FamilyXbox},
+	{VendorID: 0x0c12, ProductID: 0x8809, Name: "RedOctane Xbox Dance Pad", Family: FamilyXbox, Quirks: QuirkDancepad},
+	{VendorID: 0x0c12, ProductID: 0x880a, Name: "Pelican Eclipse PL-2023", Family: FamilyXbox},
+	{VendorID: 0x0c12, ProductID: 0x8810, Name: "Zeroplus Xbox Controller", Family: FamilyXbox},
+	{VendorID: 0x0c12, ProductID: 0x9902, Name: "HAMA VibraX - *FAULTY HARDWARE*", Family: FamilyXbox},
+	{VendorID: 0x0d2f, ProductID: 0x0002, Name: "Andamiro Pump It Up pad", Family: FamilyXbox, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x0e4c, ProductID: 0x1097, Name: "Radica Gamester Controller", Family: FamilyXbox},
+	{VendorID: 0x0e4c, ProductID: 0x1103, Name: "Radica Gamester Reflex", Family: FamilyXbox, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0e4c, ProductID: 0x2390, Name: "Radica Games Jtech Controller", Family: FamilyXbox},
+	{VendorID: 0x0e4c, ProductID: 0x3510, Name: "Radica Gamester", Family: FamilyXbox},
+	{VendorID: 0x0e6f, ProductID: 0x0003, Name: "Logic3 Freebird wireless Controller", Family: FamilyXbox},
+	{VendorID: 0x0e6f, ProductID: 0x0005, Name: "Eclipse wireless Controller", Family: FamilyXbox},
+	{VendorID: 0x0e6f, ProductID: 0x0006, Name: "Edge wireless Controller", Family: FamilyXbox},
+	{VendorID: 0x0e6f, ProductID: 0x0008, Name: "After Glow Pro Controller", Family: FamilyXbox},
+	{VendorID: 0x0e6f, ProductID: 0x0105, Name: "HSM3 Xbox360 dancepad", Family: FamilyXbox360, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x0e6f, ProductID: 0x0113, Name: "Afterglow AX.1 Gamepad for Xbox 360", Family: FamilyXbox360},
+	{VendorID: 0x0e6f, ProductID: 0x011f, Name: "Rock Candy Gamepad Wired Controller", Family: FamilyXbox360},
+	{VendorID: 0x0e6f, ProductID: 0x0131, Name: "PDP EA Sports Controller", Family: FamilyXbox360},
+	{VendorID: 0x0e6f, ProductID: 0x0133, Name: "Xbox 360 Wired Controller", Family: FamilyXbox360},
+	{VendorID: 0x0e6f, ProductID: 0x0139, Name: "Afterglow Prismatic Wired Controller", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x013a, Name: "PDP Xbox One Controller", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x0146, Name: "Rock Candy Wired Controller for Xbox One", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x0147, Name: "PDP Marvel Xbox One Controller", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x015c, Name: "PDP Xbox One Arcade Stick", Family: FamilyXboxOne, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0e6f, ProductID: 0x0161, Name: "PDP Xbox One Controller", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x0162, Name: "PDP Xbox One Controller", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x0163, Name: "PDP Xbox One Controller", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x0164, Name: "PDP Battlefield One", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x0165, Name: "PDP Titanfall 2", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x0201, Name: "Pelican PL-3601 'TSZ' Wired Xbox 360 Controller", Family: FamilyXbox360},
+	{VendorID: 0x0e6f, ProductID: 0x0213, Name: "Afterglow Gamepad for Xbox 360", Family: FamilyXbox360},
+	{VendorID: 0x0e6f, ProductID: 0x021f, Name: "Rock Candy Gamepad for Xbox 360", Family: FamilyXbox360},
+	{VendorID: 0x0e6f, ProductID: 0x0246, Name: "Rock Candy Gamepad for Xbox One 2015", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x02a0, Name: "PDP Xbox One Controller", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x02a1, Name: "PDP Xbox One Controller", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x02a2, Name: "PDP Wired Controller for Xbox One - Crimson Red", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x02a4, Name: "PDP Wired Controller for Xbox One - Stealth Series", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x02a6, Name: "PDP Wired Controller for Xbox One - Camo Series", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x02a7, Name: "PDP Xbox One Controller", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x02a8, Name: "PDP Xbox One Controller", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x02ab, Name: "PDP Controller for Xbox One", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x02ad, Name: "PDP Wired Controller for Xbox One - Stealth Series", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x02b3, Name: "Afterglow Prismatic Wired Controller", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x02b8, Name: "Afterglow Prismatic Wired Controller", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x0301, Name: "Logic3 Controller", Family: FamilyXbox360},
+	{VendorID: 0x0e6f, ProductID: 0x0346, Name: "Rock Candy Gamepad for Xbox One 2016", Family: FamilyXboxOne},
+	{VendorID: 0x0e6f, ProductID: 0x0401, Name: "Logic3 Controller", Family: FamilyXbox360},
+	{VendorID: 0x0e6f, ProductID: 0x0413, Name: "Afterglow AX.1 Gamepad for Xbox 360", Family: FamilyXbox360},
+	{VendorID: 0x0e6f, ProductID: 0x0501, Name: "PDP Xbox 360 Controller", Family: FamilyXbox360},
+	{VendorID: 0x0e6f, ProductID: 0xf900, Name: "PDP Afterglow AX.1", Family: FamilyXbox360},
+	{VendorID: 0x0e8f, ProductID: 0x0201, Name: "SmartJoy Frag Xpad/PS2 adaptor", Family: FamilyXbox},
+	{VendorID: 0x0e8f, ProductID: 0x3008, Name: "Generic xbox control (dealextreme)", Family: FamilyXbox},
+	{VendorID: 0x0f0d, ProductID: 0x000a, Name: "Hori Co. DOA4 FightStick", Family: FamilyXbox360},
+	{VendorID: 0x0f0d, ProductID: 0x000c, Name: "Hori PadEX Turbo", Family: FamilyXbox360},
+	{VendorID: 0x0f0d, ProductID: 0x000d, Name: "Hori Fighting Stick EX2", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0f0d, ProductID: 0x0016, Name: "Hori Real Arcade Pro.EX", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0f0d, ProductID: 0x001b, Name: "Hori Real Arcade Pro VX", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0f0d, ProductID: 0x0063, Name: "Hori Real Arcade Pro Hayabusa (USA) Xbox One", Family: FamilyXboxOne, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0f0d, ProductID: 0x0067, Name: "HORIPAD ONE", Family: FamilyXboxOne},
+	{VendorID: 0x0f0d, ProductID: 0x0078, Name: "Hori Real Arcade Pro V Kai Xbox One", Family: FamilyXboxOne, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0f0d, ProductID: 0x00c5, Name: "Hori Fighting Commander ONE", Family: FamilyXboxOne, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x0f30, ProductID: 0x010b, Name: "Philips Recoil", Family: FamilyXbox},
+	{VendorID: 0x0f30, ProductID: 0x0202, Name: "Joytech Advanced Controller", Family: FamilyXbox},
+	{VendorID: 0x0f30, ProductID: 0x8888, Name: "BigBen XBMiniPad Controller", Family: FamilyXbox},
+	{VendorID: 0x102c, ProductID: 0xff0c, Name: "Joytech Wireless Advanced Controller", Family: FamilyXbox},
+	{VendorID: 0x1038, ProductID: 0x1430, Name: "SteelSeries Stratus Duo", Family: FamilyXbox360},
+	{VendorID: 0x1038, ProductID: 0x1431, Name: "SteelSeries Stratus Duo", Family: FamilyXbox360},
+	{VendorID: 0x10f5, ProductID: 0x7005, Name: "Turtle Beach Recon Controller", Family: FamilyXboxOne},
+	{VendorID: 0x11c9, ProductID: 0x55f0, Name: "Nacon GC-100XF", Family: FamilyXbox360},
+	{VendorID: 0x1209, ProductID: 0x2882, Name: "Ardwiino Controller", Family: FamilyXbox360},
+	{VendorID: 0x12ab, ProductID: 0x0004, Name: "Honey Bee Xbox360 dancepad", Family: FamilyXbox360, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x12ab, ProductID: 0x0301, Name: "PDP AFTERGLOW AX.1", Family: FamilyXbox360},
+	{VendorID: 0x12ab, ProductID: 0x0303, Name: "Mortal Kombat Klassic FightStick", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x12ab, ProductID: 0x8809, Name: "Xbox DDR dancepad", Family: FamilyXbox, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x1430, ProductID: 0x4748, Name: "RedOctane Guitar Hero X-plorer", Family: FamilyXbox360},
+	{VendorID: 0x1430, ProductID: 0x8888, Name: "TX6500+ Dance Pad (first generation)", Family: FamilyXbox, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x1430, ProductID: 0xf801, Name: "RedOctane Controller", Family: FamilyXbox360},
+	{VendorID: 0x146b, ProductID: 0x0601, Name: "BigBen Interactive XBOX 360 Controller", Family: FamilyXbox360},
+	{VendorID: 0x146b, ProductID: 0x0604, Name: "Bigben Interactive DAIJA Arcade Stick", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1532, ProductID: 0x0a00, Name: "Razer Atrox Arcade Stick", Family: FamilyXboxOne, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1532, ProductID: 0x0a03, Name: "Razer Wildcat", Family: FamilyXboxOne},
+	{VendorID: 0x1532, ProductID: 0x0a29, Name: "Razer Wolverine V2", Family: FamilyXboxOne},
+	{VendorID: 0x15e4, ProductID: 0x3f00, Name: "Power A Mini Pro Elite", Family: FamilyXbox360},
+	{VendorID: 0x15e4, ProductID: 0x3f0a, Name: "Xbox Airflo wired controller", Family: FamilyXbox360},
+	{VendorID: 0x15e4, ProductID: 0x3f10, Name: "Batarang Xbox 360 controller", Family: FamilyXbox360},
+	{VendorID: 0x162e, ProductID: 0xbeef, Name: "Joytech Neo-Se Take2", Family: FamilyXbox360},
+	{VendorID: 0x1689, ProductID: 0xfd00, Name: "Razer Onza Tournament Edition", Family: FamilyXbox360},
+	{VendorID: 0x1689, ProductID: 0xfd01, Name: "Razer Onza Classic Edition", Family: FamilyXbox360},
+	{VendorID: 0x1689, ProductID: 0xfe00, Name: "Razer Sabertooth", Family: FamilyXbox360},
+	{VendorID: 0x17ef, ProductID: 0x6182, Name: "Lenovo Legion Controller for Windows", Family: FamilyXbox360},
+	{VendorID: 0x1949, ProductID: 0x041a, Name: "Amazon Game Controller", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0x0002, Name: "Harmonix Rock Band Guitar", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0x0003, Name: "Harmonix Rock Band Drumkit", Family: FamilyXbox360, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x1bad, ProductID: 0x0130, Name: "Ion Drum Rocker", Family: FamilyXbox360, Quirks: QuirkDpadToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf016, Name: "Mad Catz Xbox 360 Controller", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf018, Name: "Mad Catz Street Fighter IV SE Fighting Stick", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf019, Name: "Mad Catz Brawlstick for Xbox 360", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf021, Name: "Mad Cats Ghost Recon FS GamePad", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf023, Name: "MLG Pro Circuit Controller (Xbox)", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf025, Name: "Mad Catz Call Of Duty", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf027, Name: "Mad Catz FPS Pro", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf028, Name: "Street Fighter IV FightPad", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf02e, Name: "Mad Catz Fightpad", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf030, Name: "Mad Catz Xbox 360 MC2 MicroCon Racing Wheel", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf036, Name: "Mad Catz MicroCon GamePad Pro", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf038, Name: "Street Fighter IV FightStick TE", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf039, Name: "Mad Catz MvC2 TE", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf03a, Name: "Mad Catz SFxT Fightstick Pro", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf03d, Name: "Street Fighter IV Arcade Stick TE - Chun Li", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf03e, Name: "Mad Catz MLG FightStick TE", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf03f, Name: "Mad Catz FightStick SoulCaliber", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf042, Name: "Mad Catz FightStick TES+", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf080, Name: "Mad Catz FightStick TE2", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf501, Name: "HoriPad EX2 Turbo", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf502, Name: "Hori Real Arcade Pro.VX SA", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf503, Name: "Hori Fighting Stick VX", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf504, Name: "Hori Real Arcade Pro. EX", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf505, Name: "Hori Fighting Stick EX2B", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xf506, Name: "Hori Real Arcade Pro.EX Premium VLX", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf900, Name: "Harmonix Xbox 360 Controller", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf901, Name: "Gamestop Xbox 360 Controller", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf903, Name: "Tron Xbox 360 controller", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf904, Name: "PDP Versus Fighting Pad", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xf906, Name: "MortalKombat FightStick", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x1bad, ProductID: 0xfa01, Name: "MadCatz GamePad", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xfd00, Name: "Razer Onza TE", Family: FamilyXbox360},
+	{VendorID: 0x1bad, ProductID: 0xfd01, Name: "Razer Onza", Family: FamilyXbox360},
+	{VendorID: 0x20d6, ProductID: 0x2001, Name: "BDA Xbox Series X Wired Controller", Family: FamilyXboxSeries},
+	{VendorID: 0x20d6, ProductID: 0x2009, Name: "PowerA Enhanced Wired Controller for Xbox Series X|S", Family: FamilyXboxSeries},
+	{VendorID: 0x20d6, ProductID: 0x281f, Name: "PowerA Wired Controller For Xbox 360", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x5000, Name: "Razer Atrox Arcade Stick", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x24c6, ProductID: 0x5300, Name: "PowerA MINI PROEX Controller", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x5303, Name: "Xbox Airflo wired controller", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x530a, Name: "Xbox 360 Pro EX Controller", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x531a, Name: "PowerA Pro Ex", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x5397, Name: "FUS1ON Tournament Controller", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x541a, Name: "PowerA Xbox One Mini Wired Controller", Family: FamilyXboxOne},
+	{VendorID: 0x24c6, ProductID: 0x542a, Name: "Xbox ONE spectra", Family: FamilyXboxOne},
+	{VendorID: 0x24c6, ProductID: 0x543a, Name: "PowerA Xbox One wired controller", Family: FamilyXboxOne},
+	{VendorID: 0x24c6, ProductID: 0x5500, Name: "Hori XBOX 360 EX 2 with Turbo", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x5501, Name: "Hori Real Arcade Pro VX-SA", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x5502, Name: "Hori Fighting Stick VX Alt", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x24c6, ProductID: 0x5503, Name: "Hori Fighting Edge", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x24c6, ProductID: 0x5506, Name: "Hori SOULCALIBUR V Stick", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x550d, Name: "Hori GEM Xbox controller", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x550e, Name: "Hori Real Arcade Pro V Kai 360", Family: FamilyXbox360, Quirks: QuirkTriggersToButtons},
+	{VendorID: 0x24c6, ProductID: 0x551a, Name: "PowerA FUSION Pro Controller", Family: FamilyXboxOne},
+	{VendorID: 0x24c6, ProductID: 0x561a, Name: "PowerA FUSION Controller", Family: FamilyXboxOne},
+	{VendorID: 0x24c6, ProductID: 0x5b00, Name: "ThrustMaster Ferrari 458 Racing Wheel", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x5b02, Name: "Thrustmaster, Inc. GPX Controller", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x5b03, Name: "Thrustmaster Ferrari 458 Racing Wheel", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0x5d04, Name: "Razer Sabertooth", Family: FamilyXbox360},
+	{VendorID: 0x24c6, ProductID: 0xfafe, Name: "Rock Candy Gamepad for Xbox 360", Family: FamilyXbox360},
+	{VendorID: 0x2563, ProductID: 0x058d, Name: "OneXPlayer Gamepad", Family: FamilyXbox360},
+	{VendorID: 0x2dc8, ProductID: 0x2000, Name: "8BitDo Pro 2 Wired Controller fox Xbox", Family: FamilyXboxOne},
+	{VendorID: 0x2dc8, ProductID: 0x3106, Name: "8BitDo Ultimate Wireless / Pro 2 Wired Controller", Family: FamilyXbox360},
+	{VendorID: 0x2e24, ProductID: 0x0652, Name: "Hyperkin Duke X-Box One pad", Family: FamilyXboxOne},
+	{VendorID: 0x31e3, ProductID: 0x1100, Name: "Wooting One", Family: FamilyXbox360},
+	{VendorID: 0x3285, ProductID: 0x0607, Name: "Nacon GC-100", Family: FamilyXbox360},
+	{VendorID: 0x3537, ProductID: 0x1004, Name: "GameSir T4 Kaleid", Family: FamilyXbox360},
+	{VendorID: 0x3767, ProductID: 0x0101, Name: "Fanatec Speedster 3 Forceshock Wheel", Family: FamilyXbox},
+	{VendorID: 0xffff, ProductID: 0xffff, Name: "Chinese-made Xbox Controller", Family: FamilyXbox},
+}
@@ -0,0 +1,93 @@
+package xpad
+
+import "testing"
+
+func TestKnownDeviceTable(t *testing.T) {
+	seen := make(map[uint32]bool, len(knownDevices))
+	var prev uint32
+	for i, dev := range knownDevices {
+		key := uint32(dev.VendorID)<<16 | uint32(dev.ProductID)
+		if seen[key] {
+			t.Fatalf("duplicate entry %04x:%04x", dev.VendorID, dev.ProductID)
+		}
+		if i > 0 && key < prev {
+			t.Fatalf("entry %04x:%04x out of order", dev.VendorID, dev.ProductID)
+		}
+		if dev.Name == "" || dev.Family == FamilyUnknown {
+			t.Fatalf("entry %04x:%04x missing name or family", dev.VendorID, dev.ProductID)
+		}
+		seen[key] = true
+		prev = key
+	}
+}
+
+func TestDeviceInfoFamily(t *testing.T) {
+	cases := []struct {
+		name   string
+		info   DeviceInfo
+		family ControllerFamily
+		quirks DeviceQuirk
+		xpad   bool
+	}{
+		{
+			name:   "wired 360",
+			info:   DeviceInfo{VendorID: 0x045e, ProductID: 0x028e},
+			family: FamilyXbox360,
+			xpad:   true,
+		},
+		{
+			name:   "wireless receiver",
+			info:   DeviceInfo{VendorID: 0x045e, ProductID: 0x0719},
+			family: FamilyXbox360Wireless,
+			quirks: QuirkDpadToButtons,
+			xpad:   true,
+		},
+		{
+			name:   "PDP pad with generic name",
+			info:   DeviceInfo{VendorID: 0x0e6f, ProductID: 0x02a0, Name: "Generic X-Input Pad"},
+			family: FamilyXboxOne,
+			xpad:   true,
+		},
+		{
+			name:   "Hori stick",
+			info:   DeviceInfo{VendorID: 0x0f0d, ProductID: 0x0063},
+			family: FamilyXboxOne,
+			quirks: QuirkTriggersToButtons,
+			xpad:   true,
+		},
+		{
+			name:   "Elite 2",
+			info:   DeviceInfo{VendorID: 0x045e, ProductID: 0x0b00},
+			family: FamilyXboxOne,
+			quirks: QuirkPaddles,
+			xpad:   true,
+		},
+		{
+			name:   "Series by name fallback",
+			info:   DeviceInfo{VendorID: 0x1234, ProductID: 0x5678, Driver: "xpad", Name: "Generic Xbox Series X Controller"},
+			family: FamilyXboxSeries,
+			xpad:   true,
+		},
+		{
+			name:   "unrelated",
+			info:   DeviceInfo{VendorID: 0x046d, ProductID: 0xc52b, Name: "Logitech USB Receiver"},
+			family: FamilyUnknown,
+		},
+	}
+
+	for _, tc := range cases {
+		if got := tc.info.Family(); got != tc.family {
+			t.Fatalf("%s: Family() = %v, want %v", tc.name, got, tc.family)
+		}
+		if got := tc.info.Quirks(); got != tc.quirks {
+			t.Fatalf("%s: Quirks() = %#x, want %#x", tc.name, got, tc.quirks)
+		}
+		if got := tc.info.IsXpad(); got != tc.xpad {
+			t.Fatalf("%s: IsXpad() = %v, want %v", tc.name, got, tc.xpad)
+		}
+	}
+
+	if !QuirkDancepad.Has(QuirkSticksToNull) || QuirkDpadToButtons.Has(QuirkDancepad) {
+		t.Fatalf("DeviceQuirk.Has() mismatch")
+	}
+}
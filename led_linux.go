@@ -16,11 +16,21 @@ type LED struct {
 	BrightnessPath string
 }
 
-// OpenLED opens an LED sysfs device by path or brightness file.
+// OpenLED opens an LED sysfs device by path or brightness file. A bare name
+// such as "xpad0" is looked up under /sys/class/leds.
 func OpenLED(path string) (*LED, error) {
+	return defaultDiscoverer.OpenLED(path)
+}
+
+// OpenLED opens an LED sysfs device by path or brightness file. A bare name
+// such as "xpad0" is looked up under <root>/sys/class/leds.
+func (d *Discoverer) OpenLED(path string) (*LED, error) {
 	if path == "" {
 		return nil, ErrNotFound
 	}
+	if !strings.ContainsRune(path, filepath.Separator) {
+		path = d.path("sys/class/leds", path)
+	}
 	brightness := path
 	if filepath.Base(path) != "brightness" {
 		brightness = filepath.Join(path, "brightness")
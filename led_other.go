@@ -8,6 +8,9 @@ type LED struct{}
 // OpenLED is not supported on non-Linux platforms.
 func OpenLED(path string) (*LED, error) { return nil, ErrNotImplemented }
 
+// OpenLED is not supported on non-Linux platforms.
+func (d *Discoverer) OpenLED(path string) (*LED, error) { return nil, ErrNotImplemented }
+
 // OpenLEDDevice is not supported on non-Linux platforms.
 func OpenLEDDevice(info DeviceInfo) (*LED, error) { return nil, ErrNotImplemented }
 
@@ -0,0 +1,351 @@
+package xpad
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// modprobeConfPath is the default persistent configuration file, relative to
+// the root.
+const modprobeConfPath = "etc/modprobe.d/xpad.conf"
+
+// ModprobeConfig is a modprobe.d configuration file. Only "options xpad"
+// lines are interpreted; every other line, including comments and blank
+// lines, is kept verbatim when the file is saved.
+type ModprobeConfig struct {
+	// Path is the file read by ReadModprobeConfig and written by Save.
+	Path  string
+	lines []modprobeLine
+}
+
+type modprobeLine struct {
+	// raw is the original text, including continuation lines.
+	raw string
+	// options is non-nil for "options xpad" lines.
+	options []modprobeOption
+	dirty   bool
+}
+
+type modprobeOption struct {
+	name, value string
+}
+
+// ReadModprobeConfig reads a modprobe.d file. A missing file yields an empty
+// configuration that Save creates.
+func ReadModprobeConfig(path string) (*ModprobeConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	config := ParseModprobeConfig(string(data))
+	config.Path = path
+	return config, nil
+}
+
+// ParseModprobeConfig parses modprobe.d text.
+func ParseModprobeConfig(text string) *ModprobeConfig {
+	config := &ModprobeConfig{}
+	text = strings.TrimSuffix(text, "\n")
+	if text == "" {
+		return config
+	}
+	physical := strings.Split(text, "\n")
+	for i := 0; i < len(physical); i++ {
+		raw := physical[i]
+		logical := raw
+		// A trailing backslash continues the line.
+		for strings.HasSuffix(logical, `\`) && i+1 < len(physical) {
+			i++
+			raw += "\n" + physical[i]
+			logical = strings.TrimSuffix(logical, `\`) + " " + physical[i]
+		}
+		config.lines = append(config.lines, modprobeLine{raw: raw, options: parseOptionsLine(logical)})
+	}
+	return config
+}
+
+// parseOptionsLine returns the options of an "options xpad" line, or nil.
+func parseOptionsLine(line string) []modprobeOption {
+	fields := splitModprobeFields(line)
+	if len(fields) < 2 || fields[0] != "options" || normalizeModuleName(fields[1]) != "xpad" {
+		return nil
+	}
+	options := make([]modprobeOption, 0, len(fields)-2)
+	for _, field := range fields[2:] {
+		name, value, _ := strings.Cut(field, "=")
+		options = append(options, modprobeOption{name: name, value: strings.Trim(value, `"`)})
+	}
+	return options
+}
+
+// splitModprobeFields splits on whitespace outside double quotes and drops a
+// trailing comment.
+func splitModprobeFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	quoted := false
+	for _, r := range line {
+		switch {
+		case r == '"':
+			quoted = !quoted
+			field.WriteRune(r)
+		case r == '#' && !quoted && field.Len() == 0:
+			return fields
+		case (r == ' ' || r == '\t') && !quoted:
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteRune(r)
+		}
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// modprobe treats dashes and underscores in module names alike.
+func normalizeModuleName(name string) string {
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// Options returns the persisted xpad options. Later lines override earlier
+// ones, as in modprobe.
+func (c *ModprobeConfig) Options() map[string]string {
+	options := make(map[string]string)
+	for _, line := range c.lines {
+		for _, opt := range line.options {
+			options[opt.name] = opt.value
+		}
+	}
+	return options
+}
+
+// Get returns a persisted option value.
+func (c *ModprobeConfig) Get(name string) (string, bool) {
+	value, ok := c.Options()[name]
+	return value, ok
+}
+
+// Set persists an option. It updates the last line setting name, appends to
+// the last "options xpad" line, or adds a new one. modprobe reads the file as
+// root, so names must be letters, digits and underscores, and values must not
+// contain a newline, NUL, double quote or trailing backslash, any of which
+// could add directives to the file.
+func (c *ModprobeConfig) Set(name, value string) error {
+	if err := validateModprobeOption(name, value); err != nil {
+		return err
+	}
+	last := -1
+	for i := len(c.lines) - 1; i >= 0; i-- {
+		line := &c.lines[i]
+		if line.options == nil {
+			continue
+		}
+		if last < 0 {
+			last = i
+		}
+		for j := range line.options {
+			if line.options[j].name == name {
+				line.options[j].value = value
+				line.dirty = true
+				return nil
+			}
+		}
+	}
+	if last >= 0 {
+		c.lines[last].options = append(c.lines[last].options, modprobeOption{name: name, value: value})
+		c.lines[last].dirty = true
+		return nil
+	}
+	c.lines = append(c.lines, modprobeLine{options: []modprobeOption{{name: name, value: value}}, dirty: true})
+	return nil
+}
+
+// validateModprobeOption rejects option names and values that String could
+// not write back as a single option.
+func validateModprobeOption(name, value string) error {
+	if !isParamName(name) {
+		return fmt.Errorf("xpad: invalid modprobe option name %q", name)
+	}
+	if strings.ContainsAny(value, "\n\r\x00\"") || strings.HasSuffix(value, `\`) {
+		return fmt.Errorf("xpad: invalid value %q for modprobe option %s", value, name)
+	}
+	return nil
+}
+
+// Unset removes every setting of an option. Lines left without options are
+// dropped.
+func (c *ModprobeConfig) Unset(name string) {
+	kept := c.lines[:0]
+	for _, line := range c.lines {
+		if line.options != nil {
+			options := line.options[:0]
+			for _, opt := range line.options {
+				if opt.name != name {
+					options = append(options, opt)
+				}
+			}
+			if len(options) != len(line.options) {
+				if len(options) == 0 {
+					continue
+				}
+				line.options = options
+				line.dirty = true
+			}
+		}
+		kept = append(kept, line)
+	}
+	c.lines = kept
+}
+
+// isParamName reports whether name is a valid module parameter name: one or
+// more letters, digits and underscores.
+func isParamName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// SetModuleParams persists the boolean parameters and any Extra values. It
+// stops at the first option Set rejects.
+func (c *ModprobeConfig) SetModuleParams(params ModuleParams) error {
+	for _, p := range []struct {
+		name  string
+		value bool
+	}{
+		{"dpad_to_buttons", params.DpadToButtons},
+		{"triggers_to_buttons", params.TriggersToButtons},
+		{"sticks_to_null", params.SticksToNull},
+		{"auto_poweroff", params.AutoPowerOff},
+	} {
+		value := "0"
+		if p.value {
+			value = "1"
+		}
+		if err := c.Set(p.name, value); err != nil {
+			return err
+		}
+	}
+	names := make([]string, 0, len(params.Extra))
+	for name := range params.Extra {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := c.Set(name, params.Extra[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String formats the configuration. Untouched lines are reproduced exactly.
+func (c *ModprobeConfig) String() string {
+	var b strings.Builder
+	for _, line := range c.lines {
+		if !line.dirty {
+			b.WriteString(line.raw)
+			b.WriteByte('\n')
+			continue
+		}
+		b.WriteString("options xpad")
+		for _, opt := range line.options {
+			value := opt.value
+			if strings.ContainsAny(value, " \t#") {
+				value = `"` + value + `"`
+			}
+			fmt.Fprintf(&b, " %s=%s", opt.name, value)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Save writes the configuration to Path, replacing the file atomically.
+func (c *ModprobeConfig) Save() error {
+	if c.Path == "" {
+		return errors.New("xpad: modprobe config has no path")
+	}
+	mode := os.FileMode(0o644)
+	if fi, err := os.Stat(c.Path); err == nil {
+		mode = fi.Mode().Perm()
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(c.Path), ".xpad-*.conf")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(c.String()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.Path)
+}
+
+// ModprobeConfigPath returns <root>/etc/modprobe.d/xpad.conf.
+func (d *Discoverer) ModprobeConfigPath() string {
+	return d.path(modprobeConfPath)
+}
+
+// ReadModprobeConfig reads <root>/etc/modprobe.d/xpad.conf.
+func (d *Discoverer) ReadModprobeConfig() (*ModprobeConfig, error) {
+	return ReadModprobeConfig(d.ModprobeConfigPath())
+}
+
+// ParamDrift is a persisted option whose live value differs.
+type ParamDrift struct {
+	Name      string
+	Persisted string
+	// Live is the sysfs value, or "" if the module does not expose the
+	// parameter.
+	Live string
+}
+
+func paramValuesEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if x, ok := parseBoolValue(a); ok {
+		y, ok := parseBoolValue(b)
+		return ok && x == y
+	}
+	x, errX := strconv.ParseInt(a, 0, 64)
+	y, errY := strconv.ParseInt(b, 0, 64)
+	return errX == nil && errY == nil && x == y
+}
+
+// parseBoolValue accepts the spellings the kernel's param_set_bool does.
+func parseBoolValue(text string) (bool, bool) {
+	switch strings.ToLower(text) {
+	case "y", "yes", "1", "true", "on":
+		return true, true
+	case "n", "no", "0", "false", "off":
+		return false, true
+	default:
+		return false, false
+	}
+}
@@ -0,0 +1,154 @@
+package xpad
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestModprobeConfigEdit(t *testing.T) {
+	const original = `# xpad tuning
+blacklist xpad_old
+options xpad dpad_to_buttons=0 \
+	sticks_to_null=0
+options usbhid quirks=0x045e:0x028e:0x4
+
+options xpad foo="a b"
+`
+	cases := []struct {
+		name string
+		edit func(c *ModprobeConfig)
+		want string
+	}{
+		{
+			name: "unchanged",
+			edit: func(c *ModprobeConfig) {},
+			want: original,
+		},
+		{
+			name: "update existing",
+			edit: func(c *ModprobeConfig) { c.Set("sticks_to_null", "1") },
+			want: `# xpad tuning
+blacklist xpad_old
+options xpad dpad_to_buttons=0 sticks_to_null=1
+options usbhid quirks=0x045e:0x028e:0x4
+
+options xpad foo="a b"
+`,
+		},
+		{
+			name: "append to last options line",
+			edit: func(c *ModprobeConfig) { c.Set("auto_poweroff", "0") },
+			want: `# xpad tuning
+blacklist xpad_old
+options xpad dpad_to_buttons=0 \
+	sticks_to_null=0
+options usbhid quirks=0x045e:0x028e:0x4
+
+options xpad foo="a b" auto_poweroff=0
+`,
+		},
+		{
+			name: "unset drops empty line",
+			edit: func(c *ModprobeConfig) { c.Unset("foo") },
+			want: `# xpad tuning
+blacklist xpad_old
+options xpad dpad_to_buttons=0 \
+	sticks_to_null=0
+options usbhid quirks=0x045e:0x028e:0x4
+
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := ParseModprobeConfig(original)
+			tc.edit(config)
+			if got := config.String(); got != tc.want {
+				t.Fatalf("String() =\n%s\nwant\n%s", got, tc.want)
+			}
+		})
+	}
+
+	options := ParseModprobeConfig(original).Options()
+	want := map[string]string{"dpad_to_buttons": "0", "sticks_to_null": "0", "foo": "a b"}
+	if len(options) != len(want) {
+		t.Fatalf("Options() = %v, want %v", options, want)
+	}
+	for name, value := range want {
+		if options[name] != value {
+			t.Fatalf("Options()[%s] = %q, want %q", name, options[name], value)
+		}
+	}
+
+	empty := ParseModprobeConfig("")
+	if err := empty.SetModuleParams(ModuleParams{DpadToButtons: true}); err != nil {
+		t.Fatalf("SetModuleParams() error: %v", err)
+	}
+	const wantNew = "options xpad dpad_to_buttons=1 triggers_to_buttons=0 sticks_to_null=0 auto_poweroff=0\n"
+	if got := empty.String(); got != wantNew {
+		t.Fatalf("String() = %q, want %q", got, wantNew)
+	}
+}
+
+func TestModprobeConfigSetValidates(t *testing.T) {
+	cases := []struct {
+		name, value string
+		ok          bool
+	}{
+		{"dpad_to_buttons", "1", true},
+		{"led_pattern", "a b", true},
+		{"led_pattern", "#1", true},
+		{"", "1", false},
+		{"dpad-to-buttons", "1", false},
+		{"a=b", "1", false},
+		{"dpad_to_buttons x", "1", false},
+		{"led_pattern", "1\ninstall xpad /bin/sh -c id", false},
+		{"led_pattern", "1\rx", false},
+		{"led_pattern", "1\x00", false},
+		{"led_pattern", `a"b`, false},
+		{"led_pattern", `1\`, false},
+	}
+	for _, tc := range cases {
+		config := ParseModprobeConfig("")
+		err := config.Set(tc.name, tc.value)
+		if (err == nil) != tc.ok {
+			t.Fatalf("Set(%q, %q) error = %v, want ok %v", tc.name, tc.value, err, tc.ok)
+		}
+		if !tc.ok {
+			if got := config.String(); got != "" {
+				t.Fatalf("String() after rejected Set = %q, want empty", got)
+			}
+			continue
+		}
+		if got := ParseModprobeConfig(config.String()).Options()[tc.name]; got != tc.value {
+			t.Fatalf("round trip of %s=%q = %q", tc.name, tc.value, got)
+		}
+	}
+
+	if err := ParseModprobeConfig("").SetModuleParams(ModuleParams{Extra: map[string]string{"x": "\n"}}); err == nil {
+		t.Fatalf("SetModuleParams() with a newline value error = nil")
+	}
+}
+
+func TestModprobeConfigSave(t *testing.T) {
+	d := NewDiscoverer(t.TempDir())
+	config, err := d.ReadModprobeConfig()
+	if err != nil {
+		t.Fatalf("ReadModprobeConfig() error: %v", err)
+	}
+	if err := config.Set("dpad_to_buttons", "1"); err != nil {
+		t.Fatalf("Set() error: %v", err)
+	}
+	if err := config.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(d.Root, "etc/modprobe.d/xpad.conf"))
+	if err != nil {
+		t.Fatalf("read saved config: %v", err)
+	}
+	if string(data) != "options xpad dpad_to_buttons=1\n" {
+		t.Fatalf("saved config = %q", data)
+	}
+}
@@ -0,0 +1,96 @@
+//go:build linux
+
+package xpad
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const usbDriverDir = "sys/bus/usb/drivers/xpad"
+
+// GetModuleInfo reports whether xpad is loaded, its version and the USB
+// interfaces it is bound to.
+func GetModuleInfo() (ModuleInfo, error) {
+	return defaultDiscoverer.GetModuleInfo()
+}
+
+// RebindController unbinds and rebinds the USB interface behind info so
+// module parameter changes apply without replugging. The device's event and
+// joystick nodes are recreated, usually under new names; look it up again,
+// e.g. with FindByStableID. This typically requires root.
+func RebindController(info DeviceInfo) error {
+	return defaultDiscoverer.RebindController(info)
+}
+
+// GetModuleInfo reads the xpad module state below the root. A missing module
+// is reported as Loaded == false, not as an error.
+func (d *Discoverer) GetModuleInfo() (ModuleInfo, error) {
+	moduleDir := d.path("sys/module/xpad")
+	info := ModuleInfo{RefCount: -1}
+	if _, err := os.Stat(moduleDir); err != nil {
+		if os.IsNotExist(err) {
+			return info, nil
+		}
+		return ModuleInfo{}, err
+	}
+	info.Loaded = true
+	info.State = readTrimmedFile(filepath.Join(moduleDir, "initstate"))
+	info.BuiltIn = info.State == ""
+	info.Version = readTrimmedFile(filepath.Join(moduleDir, "version"))
+	info.SrcVersion = readTrimmedFile(filepath.Join(moduleDir, "srcversion"))
+	if refs, err := strconv.Atoi(readTrimmedFile(filepath.Join(moduleDir, "refcnt"))); err == nil {
+		info.RefCount = refs
+	}
+
+	bound, err := d.boundInterfaces()
+	if err != nil {
+		return ModuleInfo{}, err
+	}
+	info.BoundInterfaces = bound
+	return info, nil
+}
+
+// boundInterfaces lists the interface links in the driver directory; their
+// names have the form <port>:<config>.<interface>.
+func (d *Discoverer) boundInterfaces() ([]string, error) {
+	entries, err := os.ReadDir(d.path(usbDriverDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var bound []string
+	for _, entry := range entries {
+		if entry.Type()&os.ModeSymlink != 0 && strings.Contains(entry.Name(), ":") {
+			bound = append(bound, entry.Name())
+		}
+	}
+	sort.Strings(bound)
+	return bound, nil
+}
+
+// RebindController rebinds the USB interface behind info below the root.
+func (d *Discoverer) RebindController(info DeviceInfo) error {
+	intf := usbInterfacePath(info.DevicePath, d.path("sys"))
+	if intf == "" {
+		return fmt.Errorf("xpad: rebind %s: no USB interface: %w", info.Path, ErrNotFound)
+	}
+	name := filepath.Base(intf)
+	driverDir := d.path(usbDriverDir)
+
+	if _, err := os.Lstat(filepath.Join(driverDir, name)); err == nil {
+		if err := os.WriteFile(filepath.Join(driverDir, "unbind"), []byte(name), 0o200); err != nil {
+			return fmt.Errorf("xpad: unbind %s: %w", name, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(driverDir, "bind"), []byte(name), 0o200); err != nil {
+		return fmt.Errorf("xpad: bind %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+//go:build linux
+
+package xpad
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDiscovererModuleInfo(t *testing.T) {
+	cases := []struct {
+		fixture string
+		bound   []string
+	}{
+		{"xbox360-wired", []string{"1-2:1.0"}},
+		{"xbox360-wireless", []string{"1-3:1.0", "1-3:1.2", "1-3:1.4", "1-3:1.6"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.fixture, func(t *testing.T) {
+			info, err := NewDiscoverer(loadSysfsFixture(t, tc.fixture)).GetModuleInfo()
+			if err != nil {
+				t.Fatalf("GetModuleInfo() error: %v", err)
+			}
+			if !info.Loaded || info.BuiltIn || info.State != "live" || info.RefCount != 0 {
+				t.Fatalf("GetModuleInfo() = %+v, want loaded live module with refcnt 0", info)
+			}
+			if info.SrcVersion != "6F8D7C1C0E3A3B2D4E9A5F1" {
+				t.Fatalf("SrcVersion = %q", info.SrcVersion)
+			}
+			if !slices.Equal(info.BoundInterfaces, tc.bound) {
+				t.Fatalf("BoundInterfaces = %q, want %q", info.BoundInterfaces, tc.bound)
+			}
+		})
+	}
+
+	info, err := NewDiscoverer(t.TempDir()).GetModuleInfo()
+	if err != nil || info.Loaded {
+		t.Fatalf("GetModuleInfo() without module = %+v, %v, want not loaded", info, err)
+	}
+}
+
+func TestDiscovererRebindController(t *testing.T) {
+	root := loadSysfsFixture(t, "xbox360-wired")
+	d := NewDiscoverer(root)
+	infos, err := d.ListDevices()
+	if err != nil || len(infos) != 2 {
+		t.Fatalf("ListDevices() = %d, %v", len(infos), err)
+	}
+
+	if err := d.RebindController(infos[1]); err != nil {
+		t.Fatalf("RebindController() error: %v", err)
+	}
+	for _, name := range []string{"unbind", "bind"} {
+		data, err := os.ReadFile(filepath.Join(root, usbDriverDir, name))
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if string(data) != "1-2:1.0" {
+			t.Fatalf("%s = %q, want 1-2:1.0", name, data)
+		}
+	}
+
+	if err := d.RebindController(infos[0]); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("RebindController(Power Button) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDiscovererModuleParamDrift(t *testing.T) {
+	root := loadSysfsFixture(t, "xbox360-wired")
+	mustWrite(t, filepath.Join(root, "etc/modprobe.d/xpad.conf"),
+		"# persisted\noptions xpad dpad_to_buttons=1 auto_poweroff=1 quirk_level=2\n")
+
+	drift, err := NewDiscoverer(root).ModuleParamDrift()
+	if err != nil {
+		t.Fatalf("ModuleParamDrift() error: %v", err)
+	}
+	want := []ParamDrift{
+		{Name: "dpad_to_buttons", Persisted: "1", Live: "N"},
+		{Name: "quirk_level", Persisted: "2", Live: ""},
+	}
+	if !slices.Equal(drift, want) {
+		t.Fatalf("ModuleParamDrift() = %+v, want %+v", drift, want)
+	}
+}
+
+func TestDiscovererListModuleParams(t *testing.T) {
+	root := loadSysfsFixture(t, "xbox360-wired")
+	paramDir := filepath.Join(root, moduleParamDir)
+	mustWrite(t, filepath.Join(paramDir, "gip_timeout"), "250\n")
+	mustWrite(t, filepath.Join(paramDir, "led_pattern"), "rotate\n")
+	mustWrite(t, filepath.Join(paramDir, "poll_interval"), "4\n")
+	if err := os.Chmod(filepath.Join(paramDir, "gip_timeout"), 0o444); err != nil {
+		t.Fatalf("chmod: %v", err)
+	}
+	d := NewDiscoverer(root)
+
+	params, err := d.ListModuleParams()
+	if err != nil {
+		t.Fatalf("ListModuleParams() error: %v", err)
+	}
+	want := []ModuleParam{
+		{Name: "auto_poweroff", Type: ParamBool, Writable: true, Value: "Y"},
+		{Name: "dpad_to_buttons", Type: ParamBool, Writable: true, Value: "N"},
+		{Name: "gip_timeout", Type: ParamInt, Writable: false, Value: "250"},
+		{Name: "led_pattern", Type: ParamString, Writable: true, Value: "rotate"},
+		{Name: "poll_interval", Type: ParamInt, Writable: true, Value: "4"},
+		{Name: "sticks_to_null", Type: ParamBool, Writable: true, Value: "N"},
+		{Name: "triggers_to_buttons", Type: ParamBool, Writable: true, Value: "N"},
+	}
+	if !slices.Equal(params, want) {
+		t.Fatalf("ListModuleParams() = %+v, want %+v", params, want)
+	}
+
+	if v, err := d.GetIntParam("gip_timeout"); err != nil || v != 250 {
+		t.Fatalf("GetIntParam() = %d, %v, want 250", v, err)
+	}
+	if err := d.SetIntParam("poll_interval", 8); err != nil {
+		t.Fatalf("SetIntParam() error: %v", err)
+	}
+	if v, err := d.GetStringParam("poll_interval"); err != nil || v != "8" {
+		t.Fatalf("GetStringParam() = %q, %v, want 8", v, err)
+	}
+	if _, err := d.GetModuleParam("missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("GetModuleParam(missing) error = %v, want ErrNotFound", err)
+	}
+	for name, set := range map[string]func() error{
+		"SetStringParam": func() error { return d.SetStringParam("missing", "1") },
+		"SetIntParam":    func() error { return d.SetIntParam("missing", 1) },
+		"SetBoolParam":   func() error { return d.SetBoolParam("missing", true) },
+	} {
+		if err := set(); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("%s(missing) error = %v, want ErrNotFound", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(paramDir, "missing")); !os.IsNotExist(err) {
+		t.Fatalf("setters created a missing parameter: %v", err)
+	}
+
+	view, err := d.GetModuleParams()
+	if err != nil {
+		t.Fatalf("GetModuleParams() error: %v", err)
+	}
+	if len(view.Extra) != 3 || view.Extra["gip_timeout"] != "250" || view.Extra["led_pattern"] != "rotate" {
+		t.Fatalf("Extra = %v", view.Extra)
+	}
+	view.DpadToButtons = true
+	view.Extra["led_pattern"] = "blink"
+	// The unchanged read-only gip_timeout must not be written.
+	if err := d.SetModuleParams(view); err != nil {
+		t.Fatalf("SetModuleParams() error: %v", err)
+	}
+	if v, _ := d.GetStringParam("led_pattern"); v != "blink" {
+		t.Fatalf("led_pattern = %q, want blink", v)
+	}
+	if v, _ := d.GetBoolParam("dpad_to_buttons"); !v {
+		t.Fatalf("dpad_to_buttons = false, want true")
+	}
+}
+
+func TestDiscovererModuleParamsMissing(t *testing.T) {
+	root := loadSysfsFixture(t, "xbox360-wired")
+	path := filepath.Join(root, moduleParamDir, "auto_poweroff")
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	d := NewDiscoverer(root)
+
+	params, err := d.GetModuleParams()
+	if err != nil {
+		t.Fatalf("GetModuleParams() error: %v", err)
+	}
+	if params.AutoPowerOff {
+		t.Fatalf("AutoPowerOff = true, want false")
+	}
+	params.DpadToButtons = true
+	if err := d.SetModuleParams(params); err != nil {
+		t.Fatalf("SetModuleParams() error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("SetModuleParams() created auto_poweroff: %v", err)
+	}
+	if v, _ := d.GetBoolParam("dpad_to_buttons"); !v {
+		t.Fatalf("dpad_to_buttons = false, want true")
+	}
+
+	params.AutoPowerOff = true
+	if err := d.SetModuleParams(params); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("SetModuleParams(AutoPowerOff) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestModuleParamsEqual(t *testing.T) {
+	base := ModuleParams{DpadToButtons: true, Extra: map[string]string{"poll_interval": "4"}}
+	cases := []struct {
+		other ModuleParams
+		want  bool
+	}{
+		{ModuleParams{DpadToButtons: true, Extra: map[string]string{"poll_interval": "4"}}, true},
+		{ModuleParams{Extra: map[string]string{"poll_interval": "4"}}, false},
+		{ModuleParams{DpadToButtons: true, Extra: map[string]string{"poll_interval": "8"}}, false},
+		{ModuleParams{DpadToButtons: true}, false},
+	}
+	for _, tc := range cases {
+		if got := base.Equal(tc.other); got != tc.want {
+			t.Fatalf("Equal(%+v) = %v, want %v", tc.other, got, tc.want)
+		}
+	}
+	if !(ModuleParams{}).Equal(ModuleParams{Extra: map[string]string{}}) {
+		t.Fatalf("nil Extra does not equal empty Extra")
+	}
+}
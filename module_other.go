@@ -0,0 +1,23 @@
+//go:build !linux
+
+package xpad
+
+// GetModuleInfo is not supported on non-Linux platforms.
+func GetModuleInfo() (ModuleInfo, error) {
+	return ModuleInfo{}, ErrNotImplemented
+}
+
+// RebindController is not supported on non-Linux platforms.
+func RebindController(info DeviceInfo) error {
+	return ErrNotImplemented
+}
+
+// GetModuleInfo is not supported on non-Linux platforms.
+func (d *Discoverer) GetModuleInfo() (ModuleInfo, error) {
+	return ModuleInfo{}, ErrNotImplemented
+}
+
+// RebindController is not supported on non-Linux platforms.
+func (d *Discoverer) RebindController(info DeviceInfo) error {
+	return ErrNotImplemented
+}
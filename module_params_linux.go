@@ -3,62 +3,281 @@
 package xpad
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 )
 
-// ModuleParams represents tunable parameters of the xpad kernel module.
-type ModuleParams struct {
-	DpadToButtons     bool
-	TriggersToButtons bool
-	SticksToNull      bool
-	AutoPowerOff      bool
+// GetModuleParams reads the current xpad module parameters.
+func GetModuleParams() (ModuleParams, error) {
+	return defaultDiscoverer.GetModuleParams()
 }
 
-const moduleParamDir = "/sys/module/xpad/parameters"
+// SetModuleParams writes all xpad module parameters.
+//
+// This typically requires elevated permissions.
+func SetModuleParams(params ModuleParams) error {
+	return defaultDiscoverer.SetModuleParams(params)
+}
 
-// GetModuleParams reads the current xpad module parameters.
-func GetModuleParams() (ModuleParams, error) {
-	params := ModuleParams{}
-	var err error
+// ListModuleParams enumerates every xpad module parameter.
+func ListModuleParams() ([]ModuleParam, error) {
+	return defaultDiscoverer.ListModuleParams()
+}
 
-	if params.DpadToButtons, err = readBoolParam(paramPath("dpad_to_buttons")); err != nil {
-		return ModuleParams{}, err
+// GetModuleParam reads one xpad module parameter.
+func GetModuleParam(name string) (ModuleParam, error) {
+	return defaultDiscoverer.GetModuleParam(name)
+}
+
+// GetBoolParam reads a boolean xpad module parameter.
+func GetBoolParam(name string) (bool, error) {
+	return defaultDiscoverer.GetBoolParam(name)
+}
+
+// SetBoolParam writes a boolean xpad module parameter.
+func SetBoolParam(name string, value bool) error {
+	return defaultDiscoverer.SetBoolParam(name, value)
+}
+
+// GetIntParam reads an integer xpad module parameter.
+func GetIntParam(name string) (int64, error) {
+	return defaultDiscoverer.GetIntParam(name)
+}
+
+// SetIntParam writes an integer xpad module parameter.
+func SetIntParam(name string, value int64) error {
+	return defaultDiscoverer.SetIntParam(name, value)
+}
+
+// GetStringParam reads an xpad module parameter's raw value.
+func GetStringParam(name string) (string, error) {
+	return defaultDiscoverer.GetStringParam(name)
+}
+
+// SetStringParam writes an xpad module parameter's raw value.
+func SetStringParam(name, value string) error {
+	return defaultDiscoverer.SetStringParam(name, value)
+}
+
+// ListModuleParams enumerates every file under
+// <root>/sys/module/xpad/parameters, sorted by name.
+func (d *Discoverer) ListModuleParams() ([]ModuleParam, error) {
+	entries, err := os.ReadDir(d.path(moduleParamDir))
+	if err != nil {
+		return nil, err
 	}
-	if params.TriggersToButtons, err = readBoolParam(paramPath("triggers_to_buttons")); err != nil {
-		return ModuleParams{}, err
+	params := make([]ModuleParam, 0, len(entries))
+	for _, entry := range entries {
+		param, err := d.GetModuleParam(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
 	}
-	if params.SticksToNull, err = readBoolParam(paramPath("sticks_to_null")); err != nil {
-		return ModuleParams{}, err
+	return params, nil
+}
+
+// GetModuleParam reads one module parameter. A missing parameter is reported
+// as ErrNotFound.
+func (d *Discoverer) GetModuleParam(name string) (ModuleParam, error) {
+	path := d.paramPath(name)
+	fi, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return ModuleParam{}, fmt.Errorf("xpad: module parameter %s: %w", name, ErrNotFound)
+		}
+		return ModuleParam{}, err
 	}
-	if params.AutoPowerOff, err = readBoolParam(paramPath("auto_poweroff")); err != nil {
-		return ModuleParams{}, err
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ModuleParam{}, err
 	}
+	value := strings.TrimSuffix(string(data), "\n")
+	return ModuleParam{
+		Name:     name,
+		Type:     inferParamType(value),
+		Writable: fi.Mode().Perm()&0o222 != 0,
+		Value:    value,
+	}, nil
+}
 
-	return params, nil
+// inferParamType guesses the declared type: the kernel prints bool
+// parameters as Y or N and integer parameters in decimal.
+func inferParamType(value string) ModuleParamType {
+	if value == "Y" || value == "N" {
+		return ParamBool
+	}
+	if _, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return ParamInt
+	}
+	return ParamString
 }
 
-// SetModuleParams writes all xpad module parameters.
-//
-// This typically requires elevated permissions.
-func SetModuleParams(params ModuleParams) error {
-	if err := writeBoolParam(paramPath("dpad_to_buttons"), params.DpadToButtons); err != nil {
-		return err
+// GetBoolParam reads a boolean module parameter.
+func (d *Discoverer) GetBoolParam(name string) (bool, error) {
+	return readBoolParam(d.paramPath(name))
+}
+
+// SetBoolParam writes a boolean module parameter. A missing parameter is
+// reported as ErrNotFound.
+func (d *Discoverer) SetBoolParam(name string, value bool) error {
+	return writeBoolParam(d.paramPath(name), value)
+}
+
+// GetIntParam reads an integer module parameter.
+func (d *Discoverer) GetIntParam(name string) (int64, error) {
+	param, err := d.GetModuleParam(name)
+	if err != nil {
+		return 0, err
 	}
-	if err := writeBoolParam(paramPath("triggers_to_buttons"), params.TriggersToButtons); err != nil {
-		return err
+	value, err := strconv.ParseInt(strings.TrimSpace(param.Value), 0, 64)
+	if err != nil {
+		return 0, fmt.Errorf("xpad: module parameter %s: unexpected integer value %q", name, param.Value)
 	}
-	if err := writeBoolParam(paramPath("sticks_to_null"), params.SticksToNull); err != nil {
-		return err
+	return value, nil
+}
+
+// SetIntParam writes an integer module parameter.
+func (d *Discoverer) SetIntParam(name string, value int64) error {
+	return d.SetStringParam(name, strconv.FormatInt(value, 10))
+}
+
+// GetStringParam reads a module parameter's raw value.
+func (d *Discoverer) GetStringParam(name string) (string, error) {
+	param, err := d.GetModuleParam(name)
+	if err != nil {
+		return "", err
 	}
-	if err := writeBoolParam(paramPath("auto_poweroff"), params.AutoPowerOff); err != nil {
-		return err
+	return param.Value, nil
+}
+
+// SetStringParam writes a module parameter's raw value. A missing parameter
+// is reported as ErrNotFound.
+func (d *Discoverer) SetStringParam(name, value string) error {
+	return writeParam(d.paramPath(name), value)
+}
+
+// moduleParamFields maps parameter names to ModuleParams fields.
+var moduleParamFields = []struct {
+	name  string
+	field func(p *ModuleParams) *bool
+}{
+	{"dpad_to_buttons", func(p *ModuleParams) *bool { return &p.DpadToButtons }},
+	{"triggers_to_buttons", func(p *ModuleParams) *bool { return &p.TriggersToButtons }},
+	{"sticks_to_null", func(p *ModuleParams) *bool { return &p.SticksToNull }},
+	{"auto_poweroff", func(p *ModuleParams) *bool { return &p.AutoPowerOff }},
+}
+
+// GetModuleParams reads the xpad module parameters below the root. A
+// boolean parameter the running kernel does not have, such as auto_poweroff
+// on older kernels, is left false.
+func (d *Discoverer) GetModuleParams() (ModuleParams, error) {
+	params := ModuleParams{}
+	for _, known := range moduleParamFields {
+		value, err := readBoolParam(d.paramPath(known.name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return ModuleParams{}, err
+		}
+		*known.field(&params) = value
+	}
+
+	all, err := d.ListModuleParams()
+	if err != nil {
+		return ModuleParams{}, err
+	}
+	for _, param := range all {
+		if isModuleParamField(param.Name) {
+			continue
+		}
+		if params.Extra == nil {
+			params.Extra = make(map[string]string)
+		}
+		params.Extra[param.Name] = param.Value
+	}
+	return params, nil
+}
+
+// SetModuleParams writes all xpad module parameters below the root. Extra
+// parameters are written only when they differ from the live value, so a
+// read-only parameter returned by GetModuleParams does not make it fail.
+// A boolean parameter the running kernel does not have is skipped when false
+// and reported as ErrNotFound when true.
+func (d *Discoverer) SetModuleParams(params ModuleParams) error {
+	for _, known := range moduleParamFields {
+		value := *known.field(&params)
+		err := writeBoolParam(d.paramPath(known.name), value)
+		if errors.Is(err, ErrNotFound) && !value {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	names := make([]string, 0, len(params.Extra))
+	for name := range params.Extra {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if current, err := d.GetStringParam(name); err == nil && current == params.Extra[name] {
+			continue
+		}
+		if err := d.SetStringParam(name, params.Extra[name]); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
+func isModuleParamField(name string) bool {
+	for _, known := range moduleParamFields {
+		if known.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ModuleParamDrift compares the persisted options in
+// /etc/modprobe.d/xpad.conf with the live module parameters.
+func ModuleParamDrift() ([]ParamDrift, error) {
+	return defaultDiscoverer.ModuleParamDrift()
+}
+
+// ModuleParamDrift compares <root>/etc/modprobe.d/xpad.conf with the live
+// parameters below the root. Values are compared as booleans or integers
+// when both sides parse as such, so "Y" matches "1".
+func (d *Discoverer) ModuleParamDrift() ([]ParamDrift, error) {
+	config, err := d.ReadModprobeConfig()
+	if err != nil {
+		return nil, err
+	}
+	options := config.Options()
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var drift []ParamDrift
+	for _, name := range names {
+		persisted := options[name]
+		live := readTrimmedFile(d.paramPath(name))
+		if !paramValuesEqual(persisted, live) {
+			drift = append(drift, ParamDrift{Name: name, Persisted: persisted, Live: live})
+		}
+	}
+	return drift, nil
+}
+
 // GetDpadToButtons returns the dpad_to_buttons module parameter.
 func GetDpadToButtons() (bool, error) {
 	return readBoolParam(paramPath("dpad_to_buttons"))
@@ -100,7 +319,11 @@ func SetAutoPowerOff(value bool) error {
 }
 
 func paramPath(name string) string {
-	return filepath.Join(moduleParamDir, name)
+	return defaultDiscoverer.paramPath(name)
+}
+
+func (d *Discoverer) paramPath(name string) string {
+	return d.path(moduleParamDir, name)
 }
 
 func readBoolParam(path string) (bool, error) {
@@ -109,14 +332,11 @@ func readBoolParam(path string) (bool, error) {
 		return false, err
 	}
 	value := strings.TrimSpace(string(data))
-	switch strings.ToLower(value) {
-	case "y", "yes", "1", "true", "on":
-		return true, nil
-	case "n", "no", "0", "false", "off":
-		return false, nil
-	default:
+	b, ok := parseBoolValue(value)
+	if !ok {
 		return false, fmt.Errorf("xpad: unexpected boolean value %q in %s", value, path)
 	}
+	return b, nil
 }
 
 func writeBoolParam(path string, value bool) error {
@@ -124,5 +344,18 @@ func writeBoolParam(path string, value bool) error {
 	if value {
 		text = "1"
 	}
-	return os.WriteFile(path, []byte(text), 0o644)
+	return writeParam(path, text)
+}
+
+// writeParam writes an existing parameter file. Sysfs refuses to create
+// files, but below a fixture root os.WriteFile would, so a missing parameter
+// is checked for first.
+func writeParam(path, value string) error {
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("xpad: module parameter %s: %w", filepath.Base(path), ErrNotFound)
+		}
+		return err
+	}
+	return os.WriteFile(path, []byte(value), 0o644)
 }
@@ -2,14 +2,6 @@
 
 package xpad
 
-// ModuleParams represents tunable parameters of the xpad kernel module.
-type ModuleParams struct {
-	DpadToButtons     bool
-	TriggersToButtons bool
-	SticksToNull      bool
-	AutoPowerOff      bool
-}
-
 // GetModuleParams is not supported on non-Linux platforms.
 func GetModuleParams() (ModuleParams, error) {
 	return ModuleParams{}, ErrNotImplemented
@@ -20,6 +12,16 @@ func SetModuleParams(params ModuleParams) error {
 	return ErrNotImplemented
 }
 
+// GetModuleParams is not supported on non-Linux platforms.
+func (d *Discoverer) GetModuleParams() (ModuleParams, error) {
+	return ModuleParams{}, ErrNotImplemented
+}
+
+// SetModuleParams is not supported on non-Linux platforms.
+func (d *Discoverer) SetModuleParams(params ModuleParams) error {
+	return ErrNotImplemented
+}
+
 // GetDpadToButtons is not supported on non-Linux platforms.
 func GetDpadToButtons() (bool, error) {
 	return false, ErrNotImplemented
@@ -59,3 +61,93 @@ func GetAutoPowerOff() (bool, error) {
 func SetAutoPowerOff(value bool) error {
 	return ErrNotImplemented
 }
+
+// ModuleParamDrift is not supported on non-Linux platforms.
+func ModuleParamDrift() ([]ParamDrift, error) {
+	return nil, ErrNotImplemented
+}
+
+// ModuleParamDrift is not supported on non-Linux platforms.
+func (d *Discoverer) ModuleParamDrift() ([]ParamDrift, error) {
+	return nil, ErrNotImplemented
+}
+
+// ListModuleParams is not supported on non-Linux platforms.
+func ListModuleParams() ([]ModuleParam, error) {
+	return nil, ErrNotImplemented
+}
+
+// ListModuleParams is not supported on non-Linux platforms.
+func (d *Discoverer) ListModuleParams() ([]ModuleParam, error) {
+	return nil, ErrNotImplemented
+}
+
+// GetModuleParam is not supported on non-Linux platforms.
+func (d *Discoverer) GetModuleParam(name string) (ModuleParam, error) {
+	return ModuleParam{}, ErrNotImplemented
+}
+
+// GetBoolParam is not supported on non-Linux platforms.
+func (d *Discoverer) GetBoolParam(name string) (bool, error) {
+	return false, ErrNotImplemented
+}
+
+// SetBoolParam is not supported on non-Linux platforms.
+func (d *Discoverer) SetBoolParam(name string, value bool) error {
+	return ErrNotImplemented
+}
+
+// GetIntParam is not supported on non-Linux platforms.
+func (d *Discoverer) GetIntParam(name string) (int64, error) {
+	return 0, ErrNotImplemented
+}
+
+// SetIntParam is not supported on non-Linux platforms.
+func (d *Discoverer) SetIntParam(name string, value int64) error {
+	return ErrNotImplemented
+}
+
+// GetStringParam is not supported on non-Linux platforms.
+func (d *Discoverer) GetStringParam(name string) (string, error) {
+	return "", ErrNotImplemented
+}
+
+// SetStringParam is not supported on non-Linux platforms.
+func (d *Discoverer) SetStringParam(name, value string) error {
+	return ErrNotImplemented
+}
+
+// GetModuleParam is not supported on non-Linux platforms.
+func GetModuleParam(name string) (ModuleParam, error) {
+	return ModuleParam{}, ErrNotImplemented
+}
+
+// GetBoolParam is not supported on non-Linux platforms.
+func GetBoolParam(name string) (bool, error) {
+	return false, ErrNotImplemented
+}
+
+// SetBoolParam is not supported on non-Linux platforms.
+func SetBoolParam(name string, value bool) error {
+	return ErrNotImplemented
+}
+
+// GetIntParam is not supported on non-Linux platforms.
+func GetIntParam(name string) (int64, error) {
+	return 0, ErrNotImplemented
+}
+
+// SetIntParam is not supported on non-Linux platforms.
+func SetIntParam(name string, value int64) error {
+	return ErrNotImplemented
+}
+
+// GetStringParam is not supported on non-Linux platforms.
+func GetStringParam(name string) (string, error) {
+	return "", ErrNotImplemented
+}
+
+// SetStringParam is not supported on non-Linux platforms.
+func SetStringParam(name, value string) error {
+	return ErrNotImplemented
+}
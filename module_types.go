@@ -0,0 +1,83 @@
+package xpad
+
+import "maps"
+
+// ModuleInfo describes the state of the xpad kernel module, read from
+// /sys/module/xpad.
+type ModuleInfo struct {
+	// Loaded reports whether the module is present, loaded or built in.
+	Loaded bool
+	// BuiltIn reports whether xpad is compiled into the kernel; built-in
+	// modules have no initstate or refcnt.
+	BuiltIn bool
+	// State is the initstate: "live", "coming" or "going".
+	State string
+	// Version is the MODULE_VERSION string; mainline xpad does not set one.
+	Version    string
+	SrcVersion string
+	// RefCount is the module reference count, or -1 when unknown.
+	RefCount int
+	// BoundInterfaces lists the USB interfaces bound to the driver, e.g.
+	// "1-2:1.0".
+	BoundInterfaces []string
+}
+
+// ModuleParams represents tunable parameters of the xpad kernel module. It is
+// a view over ListModuleParams: the mainline boolean parameters get fields,
+// and every other parameter is kept in Extra so that a Get/Set round trip
+// preserves it. Because of Extra, ModuleParams values cannot be compared with
+// == as they could before Extra was added; use Equal.
+type ModuleParams struct {
+	DpadToButtons     bool
+	TriggersToButtons bool
+	SticksToNull      bool
+	AutoPowerOff      bool
+
+	// Extra holds the raw values of parameters without a field, such as
+	// those added by out-of-tree xpad forks.
+	Extra map[string]string
+}
+
+// Equal reports whether p and other hold the same parameter values. A nil
+// Extra equals an empty one.
+func (p ModuleParams) Equal(other ModuleParams) bool {
+	return p.DpadToButtons == other.DpadToButtons &&
+		p.TriggersToButtons == other.TriggersToButtons &&
+		p.SticksToNull == other.SticksToNull &&
+		p.AutoPowerOff == other.AutoPowerOff &&
+		maps.Equal(p.Extra, other.Extra)
+}
+
+// ModuleParamType is the inferred type of a module parameter. Sysfs does not
+// expose the declared type, so it is inferred from the current value.
+type ModuleParamType uint8
+
+const (
+	ParamString ModuleParamType = iota
+	// ParamBool values read as Y or N.
+	ParamBool
+	ParamInt
+)
+
+// String returns the type name.
+func (t ModuleParamType) String() string {
+	switch t {
+	case ParamBool:
+		return "bool"
+	case ParamInt:
+		return "int"
+	default:
+		return "string"
+	}
+}
+
+// ModuleParam is one file under /sys/module/xpad/parameters.
+type ModuleParam struct {
+	Name string
+	Type ModuleParamType
+	// Writable reports whether the parameter may be changed at runtime (it
+	// has a write permission bit); writing still usually requires root.
+	Writable bool
+	// Value is the raw sysfs value without the trailing newline.
+	Value string
+}
@@ -0,0 +1,192 @@
+package xpad
+
+// joystickAxisRange is the range joydev reports axes in after its default
+// correction, whatever the evdev range.
+const joystickAxisRange = 32767
+
+// IsTriggerAxis reports whether an absolute axis is a trigger, which
+// normalizes to [0,1] rather than [-1,1].
+func IsTriggerAxis(code uint16) bool {
+	switch code {
+	case ABSZ, ABSRZ, ABSGas, ABSBrake:
+		return true
+	default:
+		return false
+	}
+}
+
+// AxisNormalizer converts raw values of one absolute axis to float32 using
+// its AbsInfo. Sticks map to [-1,1] around the middle of the range, triggers
+// to [0,1] from the minimum. Values within Flat of the rest position read as
+// exactly 0 and the remaining travel is rescaled so the output stays
+// continuous.
+type AxisNormalizer struct {
+	Info AbsInfo
+	// Unipolar selects the [0,1] trigger range.
+	Unipolar bool
+
+	last    int32
+	hasLast bool
+}
+
+// NewAxisNormalizer returns a normalizer for the axis code, choosing the
+// trigger range for IsTriggerAxis codes.
+func NewAxisNormalizer(code uint16, info AbsInfo) *AxisNormalizer {
+	return &AxisNormalizer{Info: info, Unipolar: IsTriggerAxis(code)}
+}
+
+// Normalize maps a raw value without fuzz filtering. It has no side effects.
+func (n *AxisNormalizer) Normalize(raw int32) float32 {
+	lo, hi := float64(n.Info.Minimum), float64(n.Info.Maximum)
+	if hi <= lo {
+		return 0
+	}
+	flat := float64(n.Info.Flat)
+	if flat < 0 {
+		flat = 0
+	}
+	v := float64(raw)
+
+	if n.Unipolar {
+		travel := hi - lo - flat
+		if travel <= 0 {
+			return 0
+		}
+		return float32(clamp((v-lo-flat)/travel, 0, 1))
+	}
+
+	center := (lo + hi) / 2
+	half := (hi - lo) / 2
+	offset := v - center
+	magnitude := offset
+	if magnitude < 0 {
+		magnitude = -magnitude
+	}
+	if magnitude <= flat || half <= flat {
+		return 0
+	}
+	scaled := (magnitude - flat) / (half - flat)
+	if offset < 0 {
+		scaled = -scaled
+	}
+	return float32(clamp(scaled, -1, 1))
+}
+
+// Update filters raw with the axis Fuzz, like the kernel's input_defuzz_abs
+// does, and returns the normalized value. Changes within Fuzz/2 of the
+// previous value are dropped and changed is false.
+func (n *AxisNormalizer) Update(raw int32) (value float32, changed bool) {
+	if n.hasLast {
+		raw = defuzz(raw, n.last, n.Info.Fuzz)
+		if raw == n.last {
+			return n.Normalize(raw), false
+		}
+	}
+	n.last, n.hasLast = raw, true
+	return n.Normalize(raw), true
+}
+
+// Reset forgets the previous value used for fuzz filtering.
+func (n *AxisNormalizer) Reset() {
+	n.hasLast = false
+}
+
+// defuzz mirrors input_defuzz_abs_event in drivers/input/input.c.
+func defuzz(value, old, fuzz int32) int32 {
+	if fuzz <= 0 {
+		return value
+	}
+	switch {
+	case value > old-fuzz/2 && value < old+fuzz/2:
+		return old
+	case value > old-fuzz && value < old+fuzz:
+		return (old*3 + value) / 4
+	case value > old-fuzz*2 && value < old+fuzz*2:
+		return (old + value) / 2
+	default:
+		return value
+	}
+}
+
+func clamp(v, lo, hi float64) float64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+// Normalizer normalizes the absolute axes of one device. Build it with
+// Device.Normalizer or Joystick.Normalizer, or from recorded AbsInfo with
+// NewNormalizer.
+type Normalizer struct {
+	// Defuzz filters Event values with each axis's Fuzz. The kernel already
+	// does this for evdev events, so set it only for sources it did not
+	// filter, such as raw values read from a recording.
+	Defuzz bool
+
+	axes map[uint16]*AxisNormalizer
+	// joystickAxes maps js axis numbers to ABS_* codes (JSIOCGAXMAP).
+	joystickAxes []uint16
+}
+
+// NewNormalizer returns a normalizer for the axes in infos, keyed by ABS_*
+// code.
+func NewNormalizer(infos map[uint16]AbsInfo) *Normalizer {
+	n := &Normalizer{axes: make(map[uint16]*AxisNormalizer, len(infos))}
+	for code, info := range infos {
+		n.axes[code] = NewAxisNormalizer(code, info)
+	}
+	return n
+}
+
+// newJoystickNormalizer covers joydev's fixed output range for each axis in
+// axisMap.
+func newJoystickNormalizer(axisMap []uint8) *Normalizer {
+	n := &Normalizer{axes: make(map[uint16]*AxisNormalizer, len(axisMap))}
+	for _, code := range axisMap {
+		n.joystickAxes = append(n.joystickAxes, uint16(code))
+		n.axes[uint16(code)] = NewAxisNormalizer(uint16(code), AbsInfo{
+			Minimum: -joystickAxisRange,
+			Maximum: joystickAxisRange,
+		})
+	}
+	return n
+}
+
+// Axis returns the normalizer for an ABS_* code, or nil.
+func (n *Normalizer) Axis(code uint16) *AxisNormalizer {
+	return n.axes[code]
+}
+
+// Event normalizes an EV_ABS event. ok is false for other events and unknown
+// axes, and with Defuzz also for changes swallowed by the fuzz filter.
+func (n *Normalizer) Event(ev Event) (value float32, ok bool) {
+	if ev.Kind != EVAbs {
+		return 0, false
+	}
+	axis := n.axes[ev.Code]
+	if axis == nil {
+		return 0, false
+	}
+	if n.Defuzz {
+		return axis.Update(ev.Value)
+	}
+	return axis.Normalize(ev.Value), true
+}
+
+// JoystickEvent normalizes a joystick axis event and returns the ABS_* code
+// of the axis. ok is false for buttons and unmapped axes.
+func (n *Normalizer) JoystickEvent(ev JoystickEvent) (code uint16, value float32, ok bool) {
+	if ev.Type&^JoyEventInit != JoyEventAxis || int(ev.Number) >= len(n.joystickAxes) {
+		return 0, 0, false
+	}
+	code = n.joystickAxes[ev.Number]
+	axis := n.axes[code]
+	if axis == nil {
+		return 0, 0, false
+	}
+	return code, axis.Normalize(int32(ev.Value)), true
+}
@@ -0,0 +1,38 @@
+//go:build linux
+
+package xpad
+
+// Normalizer reads AbsInfo for every absolute axis the device advertises and
+// returns a Normalizer for its events.
+func (d *Device) Normalizer() (*Normalizer, error) {
+	if d == nil || d.file == nil {
+		return nil, ErrClosed
+	}
+	caps, err := d.capabilities(EVAbs)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := d.absInfos(caps)
+	if err != nil {
+		return nil, err
+	}
+	return NewNormalizer(infos), nil
+}
+
+// Normalizer returns a Normalizer for the joystick's axes, using its axis
+// map to identify triggers. joydev already applies the device's Flat as part
+// of its default correction, so values are scaled from its fixed output range.
+func (j *Joystick) Normalizer() (*Normalizer, error) {
+	count, err := j.Axes()
+	if err != nil {
+		return nil, err
+	}
+	axisMap, err := j.AxisMap()
+	if err != nil {
+		return nil, err
+	}
+	if int(count) < len(axisMap) {
+		axisMap = axisMap[:count]
+	}
+	return newJoystickNormalizer(axisMap), nil
+}
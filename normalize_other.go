@@ -0,0 +1,13 @@
+//go:build !linux
+
+package xpad
+
+// Normalizer is not supported on non-Linux platforms.
+func (d *Device) Normalizer() (*Normalizer, error) {
+	return nil, ErrNotImplemented
+}
+
+// Normalizer is not supported on non-Linux platforms.
+func (j *Joystick) Normalizer() (*Normalizer, error) {
+	return nil, ErrNotImplemented
+}
@@ -0,0 +1,101 @@
+package xpad
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAxisNormalizerNormalize(t *testing.T) {
+	stick := AbsInfo{Minimum: -32768, Maximum: 32767, Fuzz: 16, Flat: 128}
+	trigger := AbsInfo{Minimum: 0, Maximum: 255}
+	wideTrigger := AbsInfo{Minimum: 0, Maximum: 1023, Flat: 31}
+	cases := []struct {
+		name string
+		code uint16
+		info AbsInfo
+		raw  int32
+		want float32
+	}{
+		{"stick center", ABSX, stick, 0, 0},
+		{"stick inside flat", ABSX, stick, -128, 0},
+		{"stick max", ABSX, stick, 32767, 1},
+		{"stick min", ABSY, stick, -32768, -1},
+		{"stick half", ABSRX, stick, 16384, 0.498}, // rescaled past Flat
+		{"stick out of range", ABSRY, stick, 40000, 1},
+		{"trigger rest", ABSZ, trigger, 0, 0},
+		{"trigger full", ABSRZ, trigger, 255, 1},
+		{"trigger half", ABSRZ, trigger, 51, 0.2},
+		{"wide trigger flat", ABSZ, wideTrigger, 31, 0},
+		{"wide trigger full", ABSZ, wideTrigger, 1023, 1},
+		{"hat", ABSHat0X, AbsInfo{Minimum: -1, Maximum: 1}, -1, -1},
+		{"empty range", ABSX, AbsInfo{}, 5, 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewAxisNormalizer(tc.code, tc.info).Normalize(tc.raw)
+			if math.Abs(float64(got-tc.want)) > 0.001 {
+				t.Fatalf("Normalize(%d) = %v, want %v", tc.raw, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizerFuzz(t *testing.T) {
+	n := NewNormalizer(map[uint16]AbsInfo{ABSX: {Minimum: -100, Maximum: 100, Fuzz: 8}})
+	// The kernel has already defuzzed evdev events; Event must not do it
+	// again by default.
+	for _, raw := range []int32{0, 3, 6} {
+		got, ok := n.Event(Event{Kind: EVAbs, Code: ABSX, Value: raw})
+		if want := float32(raw) / 100; !ok || math.Abs(float64(got-want)) > 0.001 {
+			t.Fatalf("Event(%d) = %v, %v, want %v, true", raw, got, ok, want)
+		}
+	}
+
+	n.Defuzz = true
+	n.Axis(ABSX).Reset()
+	steps := []struct {
+		raw     int32
+		changed bool
+		want    float32
+	}{
+		{0, true, 0},
+		{3, false, 0},    // within fuzz/2: dropped
+		{6, true, 0.015}, // within fuzz: (0*3+6)/4 = 1
+		{50, true, 0.5},  // beyond 2*fuzz: passed through
+	}
+	for _, step := range steps {
+		got, changed := n.Event(Event{Kind: EVAbs, Code: ABSX, Value: step.raw})
+		if changed != step.changed || math.Abs(float64(got-step.want)) > 0.01 {
+			t.Fatalf("Event(%d) = %v, %v, want %v, %v", step.raw, got, changed, step.want, step.changed)
+		}
+	}
+	if _, ok := n.Event(Event{Kind: EVKey, Code: BTNA, Value: 1}); ok {
+		t.Fatalf("Event(EVKey) ok = true, want false")
+	}
+	if _, ok := n.Event(Event{Kind: EVAbs, Code: ABSY, Value: 1}); ok {
+		t.Fatalf("Event(unknown axis) ok = true, want false")
+	}
+}
+
+func TestNormalizerJoystickEvent(t *testing.T) {
+	n := newJoystickNormalizer([]uint8{ABSX, ABSY, ABSZ, ABSRX, ABSRY, ABSRZ, ABSHat0X, ABSHat0Y})
+	cases := []struct {
+		ev   JoystickEvent
+		code uint16
+		want float32
+		ok   bool
+	}{
+		{JoystickEvent{Type: JoyEventAxis, Number: 0, Value: 32767}, ABSX, 1, true},
+		{JoystickEvent{Type: JoyEventAxis | JoyEventInit, Number: 2, Value: -32767}, ABSZ, 0, true},
+		{JoystickEvent{Type: JoyEventAxis, Number: 5, Value: 32767}, ABSRZ, 1, true},
+		{JoystickEvent{Type: JoyEventAxis, Number: 8, Value: 1}, 0, 0, false},
+		{JoystickEvent{Type: JoyEventButton, Number: 0, Value: 1}, 0, 0, false},
+	}
+	for _, tc := range cases {
+		code, got, ok := n.JoystickEvent(tc.ev)
+		if ok != tc.ok || code != tc.code || math.Abs(float64(got-tc.want)) > 0.001 {
+			t.Fatalf("JoystickEvent(%+v) = %#x, %v, %v, want %#x, %v, %v", tc.ev, code, got, ok, tc.code, tc.want, tc.ok)
+		}
+	}
+}
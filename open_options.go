@@ -0,0 +1,65 @@
+package xpad
+
+// AccessMode selects the access requested when opening a device node.
+type AccessMode uint8
+
+const (
+	// AccessReadWrite opens the node with O_RDWR.
+	AccessReadWrite AccessMode = iota
+	// AccessReadOnly opens the node with O_RDONLY.
+	AccessReadOnly
+)
+
+// FallbackPolicy controls what happens when a read-write open fails.
+type FallbackPolicy uint8
+
+const (
+	// FallbackReadOnly retries with O_RDONLY when O_RDWR fails. This is the
+	// behavior of Open.
+	FallbackReadOnly FallbackPolicy = iota
+	// FallbackNone returns the read-write error instead of downgrading.
+	FallbackNone
+)
+
+// ClockID selects the clock used for evdev event timestamps (EVIOCSCLOCKID).
+type ClockID uint8
+
+const (
+	// ClockDefault leaves the kernel default (CLOCK_REALTIME) in place.
+	ClockDefault ClockID = iota
+	ClockRealtime
+	ClockMonotonic
+	ClockBoottime
+)
+
+// EventMask limits the codes of one event type delivered to a handle
+// (EVIOCSMASK). Use EVSyn as the Kind to mask whole event types.
+type EventMask struct {
+	Kind  EventKind
+	Codes []uint16
+}
+
+// Options configures how a device node is opened.
+//
+// The zero value matches Open: read-write with a read-only fallback, blocking
+// and close-on-exec.
+type Options struct {
+	Access   AccessMode
+	Fallback FallbackPolicy
+
+	// NonBlocking opens the node with O_NONBLOCK.
+	NonBlocking bool
+	// KeepOnExec clears close-on-exec so the descriptor survives exec.
+	KeepOnExec bool
+
+	// Grab requests exclusive access (EVIOCGRAB). Evdev only.
+	Grab bool
+	// Masks are applied with EVIOCSMASK. Evdev only.
+	Masks []EventMask
+	// Clock selects the event timestamp clock. Evdev only.
+	Clock ClockID
+}
+
+func (o Options) hasEvdevSettings() bool {
+	return o.Grab || len(o.Masks) > 0 || o.Clock != ClockDefault
+}
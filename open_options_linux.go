@@ -0,0 +1,41 @@
+//go:build linux
+
+package xpad
+
+import (
+	"fmt"
+	"syscall"
+)
+
+func applyDeviceOptions(d *Device, opts Options) error {
+	fd, _ := d.FD()
+	if opts.KeepOnExec {
+		if err := clearCloseOnExec(fd); err != nil {
+			return err
+		}
+	}
+	if opts.Clock != ClockDefault {
+		if err := d.SetClock(opts.Clock); err != nil {
+			return fmt.Errorf("xpad: set clock: %w", err)
+		}
+	}
+	for _, mask := range opts.Masks {
+		if err := d.SetEventMask(mask.Kind, mask.Codes); err != nil {
+			return fmt.Errorf("xpad: set event mask: %w", err)
+		}
+	}
+	if opts.Grab {
+		if err := d.Grab(true); err != nil {
+			return fmt.Errorf("xpad: grab: %w", err)
+		}
+	}
+	return nil
+}
+
+func clearCloseOnExec(fd uintptr) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_FCNTL, fd, syscall.F_SETFD, 0)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
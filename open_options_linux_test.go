@@ -0,0 +1,52 @@
+//go:build linux
+
+package xpad
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenWithOptionsReadOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "event0")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	dev, err := OpenWithOptions(path, Options{Access: AccessReadOnly, NonBlocking: true})
+	if err != nil {
+		t.Fatalf("OpenWithOptions() error: %v", err)
+	}
+	defer dev.Close()
+
+	if !dev.ReadOnly() {
+		t.Fatalf("ReadOnly() = false, want true")
+	}
+	if dev.Downgraded() {
+		t.Fatalf("Downgraded() = true for an explicit read-only open")
+	}
+	if err := dev.SendEvent(Event{Kind: EVSyn}); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("SendEvent() error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestOpenWithOptionsFailedGrabCloses(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "event0")
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// EVIOCGRAB is rejected on a regular file, so the open must fail as a whole.
+	if dev, err := OpenWithOptions(path, Options{Grab: true}); err == nil {
+		dev.Close()
+		t.Fatalf("OpenWithOptions(Grab) succeeded on a regular file")
+	}
+}
+
+func TestOpenJoystickWithOptionsRejectsEvdevSettings(t *testing.T) {
+	if _, err := OpenJoystickWithOptions("/nonexistent/js0", Options{Clock: ClockMonotonic}); err == nil {
+		t.Fatalf("OpenJoystickWithOptions(Clock) error = nil, want error")
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package xpad
+
+func applyDeviceOptions(d *Device, opts Options) error {
+	if opts.KeepOnExec || opts.hasEvdevSettings() {
+		return ErrNotImplemented
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package xpad
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseProcInputDevices parses the /proc/bus/input/devices format. Handler
+// names become /dev/input paths: the first eventN handler sets Path and the
+// first jsN handler sets JoystickPath. Devices without an event handler are
+// returned with an empty Path.
+func ParseProcInputDevices(r io.Reader) ([]DeviceInfo, error) {
+	return defaultDiscoverer.parseProcInputDevices(r)
+}
+
+func (d *Discoverer) parseProcInputDevices(r io.Reader) ([]DeviceInfo, error) {
+	var infos []DeviceInfo
+	var info DeviceInfo
+	inDevice := false
+	flush := func() {
+		if inDevice {
+			infos = append(infos, info)
+		}
+		info, inDevice = DeviceInfo{}, false
+	}
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			flush()
+			continue
+		}
+		tag, rest, ok := strings.Cut(line, ": ")
+		if !ok || len(tag) != 1 {
+			return nil, fmt.Errorf("xpad: input devices line %d: malformed %q", lineNo, line)
+		}
+		inDevice = true
+		if err := d.parseProcLine(&info, tag[0], rest); err != nil {
+			return nil, fmt.Errorf("xpad: input devices line %d: %w", lineNo, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return infos, nil
+}
+
+func (d *Discoverer) parseProcLine(info *DeviceInfo, tag byte, rest string) error {
+	key, value, _ := strings.Cut(rest, "=")
+	switch tag {
+	case 'I':
+		for _, field := range strings.Fields(rest) {
+			name, text, _ := strings.Cut(field, "=")
+			id, err := strconv.ParseUint(text, 16, 16)
+			if err != nil {
+				return fmt.Errorf("malformed %s: %w", name, err)
+			}
+			switch name {
+			case "Bus":
+				info.BusType = uint16(id)
+			case "Vendor":
+				info.VendorID = uint16(id)
+			case "Product":
+				info.ProductID = uint16(id)
+			case "Version":
+				info.VersionID = uint16(id)
+			}
+		}
+	case 'N':
+		info.Name = strings.Trim(value, `"`)
+	case 'P':
+		info.Phys = value
+	case 'S':
+		if value != "" {
+			info.DevicePath = d.path("sys", value)
+		}
+	case 'U':
+		info.Uniq = value
+	case 'H':
+		info.Handlers = strings.Fields(value)
+		for _, handler := range info.Handlers {
+			switch {
+			case strings.HasPrefix(handler, "event") && info.Path == "":
+				info.Path = d.path("dev/input", handler)
+			case strings.HasPrefix(handler, "js") && info.JoystickPath == "":
+				info.JoystickPath = d.path("dev/input", handler)
+			}
+		}
+	case 'B':
+		bitmap, err := parseSysfsBitmap(value)
+		if err != nil {
+			return err
+		}
+		setProcBitmap(&info.Capabilities, strings.ToLower(key), bitmap)
+	}
+	return nil
+}
+
+func setProcBitmap(caps *Capabilities, name string, bitmap []byte) {
+	switch name {
+	case "ev":
+		caps.Types = bitmap
+		return
+	case "prop":
+		caps.Properties = bitmap
+		return
+	}
+	for _, file := range capabilityFiles {
+		if file.name != name {
+			continue
+		}
+		if caps.Codes == nil {
+			caps.Codes = make(map[EventKind][]byte)
+		}
+		caps.Codes[file.kind] = bitmap
+	}
+}
@@ -0,0 +1,267 @@
+package xpad
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Query selects devices from a discovery listing. Build one with NewQuery and
+// chain filters; every filter must match for a device to be selected.
+//
+//	q := xpad.NewQuery().Family(xpad.FamilyXboxOne).HasJoystick().OrderByStableID()
+//	devices, err := xpad.QueryDevices(q)
+type Query struct {
+	filters []queryFilter
+	ordered bool
+	err     error
+}
+
+// queryFilter returns an empty string when info matches and the rejection
+// reason otherwise.
+type queryFilter func(info DeviceInfo) string
+
+// NewQuery returns a query that matches every device.
+func NewQuery() *Query {
+	return &Query{}
+}
+
+func (q *Query) add(filter queryFilter) *Query {
+	q.filters = append(q.filters, filter)
+	return q
+}
+
+// Where adds a custom filter. reason describes the requirement in rejection
+// reports, e.g. "battery powered".
+func (q *Query) Where(reason string, match func(DeviceInfo) bool) *Query {
+	return q.add(func(info DeviceInfo) string {
+		if match(info) {
+			return ""
+		}
+		return "not " + reason
+	})
+}
+
+// Xpad matches devices that appear to be handled by the xpad driver.
+func (q *Query) Xpad() *Query {
+	return q.add(func(info DeviceInfo) string {
+		if info.IsXpad() {
+			return ""
+		}
+		return "not an xpad device"
+	})
+}
+
+// VendorProduct matches a vendor/product pair.
+func (q *Query) VendorProduct(vendor, product uint16) *Query {
+	return q.add(func(info DeviceInfo) string {
+		if info.VendorID == vendor && info.ProductID == product {
+			return ""
+		}
+		return fmt.Sprintf("id %04x:%04x is not %04x:%04x", info.VendorID, info.ProductID, vendor, product)
+	})
+}
+
+// Vendor matches a vendor ID.
+func (q *Query) Vendor(vendor uint16) *Query {
+	return q.add(func(info DeviceInfo) string {
+		if info.VendorID == vendor {
+			return ""
+		}
+		return fmt.Sprintf("vendor %04x is not %04x", info.VendorID, vendor)
+	})
+}
+
+// Product matches a product ID.
+func (q *Query) Product(product uint16) *Query {
+	return q.add(func(info DeviceInfo) string {
+		if info.ProductID == product {
+			return ""
+		}
+		return fmt.Sprintf("product %04x is not %04x", info.ProductID, product)
+	})
+}
+
+// Bus matches any of the bus types (BusUSB, BusBluetooth, ...).
+func (q *Query) Bus(buses ...uint16) *Query {
+	return q.add(func(info DeviceInfo) string {
+		for _, bus := range buses {
+			if info.BusType == bus {
+				return ""
+			}
+		}
+		return fmt.Sprintf("bus %#04x is not one of %#04x", info.BusType, buses)
+	})
+}
+
+// NameGlob matches the device name against a path.Match pattern such as
+// "*Xbox*". A malformed pattern is reported when the query runs.
+func (q *Query) NameGlob(pattern string) *Query {
+	if _, err := path.Match(pattern, ""); err != nil && q.err == nil {
+		q.err = fmt.Errorf("xpad: name glob %q: %w", pattern, err)
+	}
+	return q.add(func(info DeviceInfo) string {
+		if ok, _ := path.Match(pattern, info.Name); ok {
+			return ""
+		}
+		return fmt.Sprintf("name %q does not match %q", info.Name, pattern)
+	})
+}
+
+// NameRegexp matches the device name against a regular expression. A
+// malformed expression is reported when the query runs.
+func (q *Query) NameRegexp(expr string) *Query {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		if q.err == nil {
+			q.err = fmt.Errorf("xpad: name regexp %q: %w", expr, err)
+		}
+		return q
+	}
+	return q.add(func(info DeviceInfo) string {
+		if re.MatchString(info.Name) {
+			return ""
+		}
+		return fmt.Sprintf("name %q does not match /%s/", info.Name, expr)
+	})
+}
+
+// HasJoystick matches devices with a /dev/input/jsX node.
+func (q *Query) HasJoystick() *Query {
+	return q.add(func(info DeviceInfo) string {
+		if info.JoystickPath != "" {
+			return ""
+		}
+		return "no joystick node"
+	})
+}
+
+// HasLED matches devices with an xpad LED.
+func (q *Query) HasLED() *Query {
+	return q.add(func(info DeviceInfo) string {
+		if info.LEDPath != "" {
+			return ""
+		}
+		return "no LED"
+	})
+}
+
+// Seat matches devices assigned to a udev seat; see UdevData.Seat.
+func (q *Query) Seat(seat string) *Query {
+	return q.add(func(info DeviceInfo) string {
+		if info.Seat() == seat {
+			return ""
+		}
+		return fmt.Sprintf("seat %q is not %q", info.Seat(), seat)
+	})
+}
+
+// Capability matches devices advertising every listed code of an event type.
+// With no codes it only requires the event type.
+func (q *Query) Capability(ev EventKind, codes ...uint16) *Query {
+	return q.add(func(info DeviceInfo) string {
+		if !info.Capabilities.HasEventType(ev) {
+			return fmt.Sprintf("no event type %#x", uint16(ev))
+		}
+		for _, code := range codes {
+			if !info.Capabilities.HasEventCode(ev, code) {
+				return fmt.Sprintf("no event code %#x/%#x", uint16(ev), code)
+			}
+		}
+		return ""
+	})
+}
+
+// Family matches any of the controller families.
+func (q *Query) Family(families ...ControllerFamily) *Query {
+	return q.add(func(info DeviceInfo) string {
+		family := info.Family()
+		for _, want := range families {
+			if family == want {
+				return ""
+			}
+		}
+		return fmt.Sprintf("family %s is not one of %v", family, families)
+	})
+}
+
+// OrderByStableID sorts matches by DeviceInfo.StableID instead of by path, so
+// the same controller comes first across replugs. Devices without a stable
+// ID sort last.
+func (q *Query) OrderByStableID() *Query {
+	q.ordered = true
+	return q
+}
+
+// Rejection records why a device did not match a query.
+type Rejection struct {
+	Device DeviceInfo
+	Reason string
+}
+
+// NoMatchError is returned when no device matches a query. It matches
+// ErrNotFound with errors.Is.
+type NoMatchError struct {
+	Rejected []Rejection
+}
+
+func (e *NoMatchError) Error() string {
+	if len(e.Rejected) == 0 {
+		return "xpad: no device matches query: no candidates"
+	}
+	var b strings.Builder
+	b.WriteString("xpad: no device matches query; rejected:")
+	for _, r := range e.Rejected {
+		fmt.Fprintf(&b, " %s (%s): %s;", r.Device.Path, r.Device.Name, r.Reason)
+	}
+	return strings.TrimSuffix(b.String(), ";")
+}
+
+func (e *NoMatchError) Unwrap() error {
+	return ErrNotFound
+}
+
+// Filter returns the devices matching q, in order. If none match it returns a
+// *NoMatchError listing each candidate with the first filter it failed. A nil
+// query matches every device, like NewQuery().
+func (q *Query) Filter(infos []DeviceInfo) ([]DeviceInfo, error) {
+	if q == nil {
+		q = NewQuery()
+	}
+	if q.err != nil {
+		return nil, q.err
+	}
+	var matched []DeviceInfo
+	var rejected []Rejection
+	for _, info := range infos {
+		if reason := q.reject(info); reason != "" {
+			rejected = append(rejected, Rejection{Device: info, Reason: reason})
+			continue
+		}
+		matched = append(matched, info)
+	}
+	if len(matched) == 0 {
+		return nil, &NoMatchError{Rejected: rejected}
+	}
+	if q.ordered {
+		sort.SliceStable(matched, func(i, j int) bool {
+			a, b := matched[i].StableID(), matched[j].StableID()
+			if (a == "") != (b == "") {
+				return b == ""
+			}
+			return a < b
+		})
+	}
+	return matched, nil
+}
+
+func (q *Query) reject(info DeviceInfo) string {
+	for _, filter := range q.filters {
+		if reason := filter(info); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
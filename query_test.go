@@ -0,0 +1,106 @@
+package xpad
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQueryFilter(t *testing.T) {
+	var pad Capabilities
+	pad.Types = bitsetSet(bitsetSet(nil, uint16(EVKey)), uint16(EVAbs))
+	pad.setCode(EVKey, BTNGamepad)
+	pad.setCode(EVAbs, ABSHat0X)
+
+	usb := USBInfo{SysfsPath: "/sys/devices/usb1/1-4", InterfaceNumber: 0}
+	wired := DeviceInfo{
+		Path: "/dev/input/event5", Name: "Microsoft X-Box 360 pad", BusType: BusUSB,
+		VendorID: 0x045e, ProductID: 0x028e, JoystickPath: "/dev/input/js0",
+		LEDPath: "/sys/class/leds/xpad0", Capabilities: pad,
+		USB: USBInfo{SysfsPath: "/sys/devices/usb1/1-2", Serial: "B"},
+	}
+	one := DeviceInfo{
+		Path: "/dev/input/event9", Name: "Microsoft X-Box One pad", BusType: BusUSB,
+		VendorID: 0x045e, ProductID: 0x02ea, JoystickPath: "/dev/input/js2",
+		Capabilities: pad, USB: USBInfo{SysfsPath: usb.SysfsPath, Serial: "A"},
+	}
+	button := DeviceInfo{Path: "/dev/input/event0", Name: "Power Button", BusType: 0x19}
+	devices := []DeviceInfo{button, wired, one}
+
+	cases := []struct {
+		name  string
+		query *Query
+		want  []string
+	}{
+		{"all", NewQuery(), []string{"/dev/input/event0", "/dev/input/event5", "/dev/input/event9"}},
+		{"xpad", NewQuery().Xpad(), []string{"/dev/input/event5", "/dev/input/event9"}},
+		{"vid pid", NewQuery().VendorProduct(0x045e, 0x02ea), []string{"/dev/input/event9"}},
+		{"vendor", NewQuery().Vendor(0x045e), []string{"/dev/input/event5", "/dev/input/event9"}},
+		{"product", NewQuery().Product(0x028e), []string{"/dev/input/event5"}},
+		{"bus", NewQuery().Bus(BusUSB, BusBluetooth), []string{"/dev/input/event5", "/dev/input/event9"}},
+		{"glob", NewQuery().NameGlob("*360*"), []string{"/dev/input/event5"}},
+		{"regexp", NewQuery().NameRegexp(`(?i)^power`), []string{"/dev/input/event0"}},
+		{"joystick", NewQuery().HasJoystick(), []string{"/dev/input/event5", "/dev/input/event9"}},
+		{"led", NewQuery().HasLED(), []string{"/dev/input/event5"}},
+		{"capability", NewQuery().Capability(EVAbs, ABSHat0X), []string{"/dev/input/event5", "/dev/input/event9"}},
+		{"family", NewQuery().Family(FamilyXboxOne, FamilyXboxSeries), []string{"/dev/input/event9"}},
+		{"where", NewQuery().Where("named", func(info DeviceInfo) bool { return info.Name == "Power Button" }), []string{"/dev/input/event0"}},
+		{"stable order", NewQuery().OrderByStableID(), []string{"/dev/input/event5", "/dev/input/event9", "/dev/input/event0"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.query.Filter(devices)
+			if err != nil {
+				t.Fatalf("Filter() error: %v", err)
+			}
+			paths := make([]string, len(got))
+			for i, info := range got {
+				paths[i] = info.Path
+			}
+			if strings.Join(paths, ",") != strings.Join(tc.want, ",") {
+				t.Fatalf("Filter() = %v, want %v", paths, tc.want)
+			}
+		})
+	}
+}
+
+func TestQueryNoMatch(t *testing.T) {
+	devices := []DeviceInfo{
+		{Path: "/dev/input/event0", Name: "Power Button"},
+		{Path: "/dev/input/event5", Name: "Xbox 360 pad", VendorID: 0x045e, ProductID: 0x028e},
+	}
+	_, err := NewQuery().Xpad().HasLED().Filter(devices)
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Filter() error = %v, want ErrNotFound", err)
+	}
+	var noMatch *NoMatchError
+	if !errors.As(err, &noMatch) {
+		t.Fatalf("Filter() error = %T, want *NoMatchError", err)
+	}
+	want := []string{"not an xpad device", "no LED"}
+	if len(noMatch.Rejected) != len(want) {
+		t.Fatalf("Rejected = %+v, want %d entries", noMatch.Rejected, len(want))
+	}
+	for i, r := range noMatch.Rejected {
+		if r.Reason != want[i] {
+			t.Fatalf("Rejected[%d].Reason = %q, want %q", i, r.Reason, want[i])
+		}
+	}
+
+	if _, err := NewQuery().NameRegexp("(").Filter(devices); err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("Filter() with bad regexp error = %v, want compile error", err)
+	}
+	if _, err := NewQuery().NameGlob("[").Filter(devices); err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("Filter() with bad glob error = %v, want pattern error", err)
+	}
+}
+
+func TestQueryNil(t *testing.T) {
+	devices := []DeviceInfo{{Path: "/dev/input/event0"}, {Path: "/dev/input/event5"}}
+	var q *Query
+	got, err := q.Filter(devices)
+	if err != nil || len(got) != len(devices) {
+		t.Fatalf("nil Filter() = %v, %v, want all devices", got, err)
+	}
+}
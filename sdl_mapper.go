@@ -0,0 +1,223 @@
+package xpad
+
+// btnJoystick is BTN_JOYSTICK, where SDL starts numbering buttons.
+const btnJoystick = 0x120
+
+// SDLMapper applies an SDL mapping to evdev events and tracks the resulting
+// GamepadState. Button, axis and hat numbers are assigned from the device's
+// capabilities the way SDL's Linux backend does.
+type SDLMapper struct {
+	Mapping *SDLMapping
+
+	buttons map[uint16]int
+	axes    map[uint16]int
+	hats    map[uint16]int
+	norm    map[uint16]*AxisNormalizer
+
+	hatState []uint8
+	active   []bool
+	state    GamepadState
+}
+
+// NewSDLMapper returns a mapper for a device with the given capabilities.
+// mapping must not be nil. absInfo supplies the range of each absolute axis;
+// like SDL, every axis is treated as bipolar and Flat is ignored, since SDL
+// applies Linux deadzones only when SDL_HINT_LINUX_JOYSTICK_DEADZONES is set.
+// Apply a StickConfig to the output for a deadzone.
+func NewSDLMapper(mapping *SDLMapping, caps Capabilities, absInfo map[uint16]AbsInfo) *SDLMapper {
+	m := &SDLMapper{
+		Mapping: mapping,
+		buttons: make(map[uint16]int),
+		axes:    make(map[uint16]int),
+		hats:    make(map[uint16]int),
+		norm:    make(map[uint16]*AxisNormalizer),
+		active:  make([]bool, len(mapping.Bindings)),
+	}
+
+	// SDL numbers BTN_JOYSTICK and above first, then the lower key codes.
+	keys := caps.EventCodes(EVKey)
+	for _, code := range keys {
+		if code >= btnJoystick {
+			m.buttons[code] = len(m.buttons)
+		}
+	}
+	for _, code := range keys {
+		if code < btnJoystick {
+			m.buttons[code] = len(m.buttons)
+		}
+	}
+
+	for _, code := range caps.EventCodes(EVAbs) {
+		if code >= ABSHat0X && code <= ABSHat3Y {
+			continue
+		}
+		m.axes[code] = len(m.axes)
+		info := absInfo[code]
+		info.Flat = 0
+		m.norm[code] = &AxisNormalizer{Info: info}
+	}
+	for code := uint16(ABSHat0X); code <= ABSHat3Y; code += 2 {
+		if caps.HasEventCode(EVAbs, code) || caps.HasEventCode(EVAbs, code+1) {
+			m.hats[code], m.hats[code+1] = len(m.hatState), len(m.hatState)
+			m.hatState = append(m.hatState, 0)
+		}
+	}
+	return m
+}
+
+// State returns the current state of the logical controls.
+func (m *SDLMapper) State() GamepadState {
+	return m.state
+}
+
+// Apply processes an evdev event and returns the logical controls it
+// changed.
+func (m *SDLMapper) Apply(ev Event) []ControlEvent {
+	var changes []ControlEvent
+	switch ev.Kind {
+	case EVKey:
+		index, ok := m.buttons[ev.Code]
+		if !ok {
+			return nil
+		}
+		pressed := ev.Value != KeyReleased
+		for i, b := range m.Mapping.Bindings {
+			if b.Input == SDLInputButton && b.Index == index {
+				changes = m.applyDigital(changes, ev, i, pressed)
+			}
+		}
+	case EVAbs:
+		if hat, ok := m.hats[ev.Code]; ok {
+			m.hatState[hat] = updateHat(m.hatState[hat], ev.Code, ev.Value)
+			for i, b := range m.Mapping.Bindings {
+				if b.Input == SDLInputHat && b.Index == hat {
+					changes = m.applyDigital(changes, ev, i, m.hatState[hat]&b.HatMask != 0)
+				}
+			}
+			return changes
+		}
+		index, ok := m.axes[ev.Code]
+		if !ok {
+			return nil
+		}
+		value := m.norm[ev.Code].Normalize(ev.Value)
+		for i, b := range m.Mapping.Bindings {
+			if b.Input == SDLInputAxis && b.Index == index {
+				changes = m.applyAnalog(changes, ev, i, value)
+			}
+		}
+	}
+	return changes
+}
+
+// updateHat sets the SDL hat bits (1 up, 2 right, 4 down, 8 left) for one
+// hat axis.
+func updateHat(bits uint8, code uint16, value int32) uint8 {
+	if (code-ABSHat0X)%2 == 0 {
+		bits &^= 2 | 8
+		switch {
+		case value < 0:
+			bits |= 8
+		case value > 0:
+			bits |= 2
+		}
+		return bits
+	}
+	bits &^= 1 | 4
+	switch {
+	case value < 0:
+		bits |= 1
+	case value > 0:
+		bits |= 4
+	}
+	return bits
+}
+
+// applyDigital applies a button or hat input to binding i.
+func (m *SDLMapper) applyDigital(changes []ControlEvent, ev Event, i int, pressed bool) []ControlEvent {
+	b := m.Mapping.Bindings[i]
+	if !b.IsAxis {
+		return m.setButton(changes, ev, b.Button, pressed)
+	}
+	value := float32(0)
+	if pressed {
+		_, value = b.outputRange()
+	}
+	return m.setAxis(changes, ev, b.Axis, value)
+}
+
+// applyAnalog applies a normalized axis input to binding i. Values outside
+// a half-axis input range are ignored, except that the output returns to
+// rest when the input leaves the range.
+func (m *SDLMapper) applyAnalog(changes []ControlEvent, ev Event, i int, value float32) []ControlEvent {
+	b := m.Mapping.Bindings[i]
+	inMin, inMax := b.inputRange()
+	if value < min(inMin, inMax) || value > max(inMin, inMax) {
+		if !m.active[i] {
+			return changes
+		}
+		m.active[i] = false
+		if b.IsAxis {
+			return m.setAxis(changes, ev, b.Axis, 0)
+		}
+		return m.setButton(changes, ev, b.Button, false)
+	}
+	m.active[i] = true
+
+	if !b.IsAxis {
+		threshold := inMin + (inMax-inMin)/2
+		pressed := value >= threshold
+		if inMax < inMin {
+			pressed = value <= threshold
+		}
+		return m.setButton(changes, ev, b.Button, pressed)
+	}
+	outMin, outMax := b.outputRange()
+	out := outMin + (value-inMin)*(outMax-outMin)/(inMax-inMin)
+	return m.setAxis(changes, ev, b.Axis, out)
+}
+
+func (m *SDLMapper) setButton(changes []ControlEvent, ev Event, button Button, pressed bool) []ControlEvent {
+	if change, ok := m.state.setButton(ev.When, button, pressed); ok {
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func (m *SDLMapper) setAxis(changes []ControlEvent, ev Event, axis Axis, value float32) []ControlEvent {
+	if change, ok := m.state.setAxis(ev.When, axis, value); ok {
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// inputRange returns the normalized input range of an axis binding, from
+// rest to full deflection for half axes.
+func (b SDLBinding) inputRange() (from, to float32) {
+	switch b.InputHalf {
+	case 1:
+		from, to = 0, 1
+	case -1:
+		from, to = 0, -1
+	default:
+		from, to = -1, 1
+	}
+	if b.Invert {
+		from, to = to, from
+	}
+	return from, to
+}
+
+// outputRange returns the output range of an axis binding.
+func (b SDLBinding) outputRange() (from, to float32) {
+	switch {
+	case b.Axis == AxisLeftTrigger || b.Axis == AxisRightTrigger:
+		return 0, 1
+	case b.OutputHalf > 0:
+		return 0, 1
+	case b.OutputHalf < 0:
+		return 0, -1
+	default:
+		return -1, 1
+	}
+}
@@ -0,0 +1,363 @@
+package xpad
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SDLGUID is an SDL joystick GUID, as used by SDL_GameControllerDB.
+type SDLGUID [16]byte
+
+// NewSDLGUID computes the GUID SDL's Linux backend gives a device, following
+// SDL_CreateJoystickGUID: bus, CRC-16 of the name, then vendor, product and
+// version, or the start of the name when the IDs are zero. SDL may compute
+// the CRC from a cleaned-up name; lookups ignore the CRC when either side
+// lacks one, so this does not affect matching.
+func NewSDLGUID(id InputID, name string) SDLGUID {
+	var guid SDLGUID
+	binary.LittleEndian.PutUint16(guid[0:], id.BusType)
+	binary.LittleEndian.PutUint16(guid[2:], sdlCRC16([]byte(name)))
+	if id.Vendor != 0 && id.Product != 0 {
+		binary.LittleEndian.PutUint16(guid[4:], id.Vendor)
+		binary.LittleEndian.PutUint16(guid[8:], id.Product)
+		binary.LittleEndian.PutUint16(guid[12:], id.Version)
+		return guid
+	}
+	// strlcpy into the 12 remaining bytes keeps room for the terminator.
+	copy(guid[4:15], name)
+	return guid
+}
+
+// ParseSDLGUID parses the 32 hex digit form.
+func ParseSDLGUID(text string) (SDLGUID, error) {
+	var guid SDLGUID
+	if len(text) != 2*len(guid) {
+		return guid, fmt.Errorf("xpad: invalid SDL GUID %q", text)
+	}
+	if _, err := hex.Decode(guid[:], []byte(text)); err != nil {
+		return guid, fmt.Errorf("xpad: invalid SDL GUID %q: %w", text, err)
+	}
+	return guid, nil
+}
+
+// String returns the 32 hex digit form used in mapping strings.
+func (g SDLGUID) String() string {
+	return hex.EncodeToString(g[:])
+}
+
+// CRC returns the name CRC stored in the GUID, or 0.
+func (g SDLGUID) CRC() uint16 {
+	return binary.LittleEndian.Uint16(g[2:])
+}
+
+// hasIDs reports whether the GUID holds vendor, product and version rather
+// than a name.
+func (g SDLGUID) hasIDs() bool {
+	return binary.LittleEndian.Uint16(g[4:]) != 0 && binary.LittleEndian.Uint16(g[8:]) != 0 &&
+		g[6] == 0 && g[7] == 0 && g[10] == 0 && g[11] == 0
+}
+
+func (g SDLGUID) withCRC(crc uint16) SDLGUID {
+	binary.LittleEndian.PutUint16(g[2:], crc)
+	return g
+}
+
+func (g SDLGUID) withoutVersion() SDLGUID {
+	binary.LittleEndian.PutUint16(g[12:], 0)
+	return g
+}
+
+// sdlCRC16 is SDL_crc16: CRC-16/ARC (reflected polynomial 0xA001, initial
+// value 0).
+func sdlCRC16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b)
+		for i := 0; i < 8; i++ {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0xa001
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return crc
+}
+
+// SDLInputKind is the kind of joystick input an SDL binding reads.
+type SDLInputKind uint8
+
+const (
+	SDLInputButton SDLInputKind = iota
+	SDLInputAxis
+	SDLInputHat
+)
+
+// SDLBinding maps one SDL joystick input to a logical control, e.g.
+// "lefttrigger:a2" or "dpup:h0.1".
+type SDLBinding struct {
+	// IsAxis selects Axis as the output; otherwise Button.
+	IsAxis bool
+	Button Button
+	Axis   Axis
+	// OutputHalf is +1 or -1 for "+leftx"/"-leftx" style outputs that drive
+	// half an axis, else 0.
+	OutputHalf int8
+
+	Input SDLInputKind
+	// Index is the SDL button, axis or hat number.
+	Index int
+	// HatMask is the hat direction for hat inputs: 1 up, 2 right, 4 down,
+	// 8 left.
+	HatMask uint8
+	// InputHalf is +1 or -1 for "+a1"/"-a1" style half-axis inputs, else 0.
+	InputHalf int8
+	// Invert is set by a trailing "~" on an axis input.
+	Invert bool
+}
+
+// SDLMapping is one SDL_GameControllerDB entry.
+type SDLMapping struct {
+	GUID     SDLGUID
+	Name     string
+	Platform string
+	Bindings []SDLBinding
+	// Extra holds fields that are not bindings, such as "hint", and bindings
+	// for controls without a logical equivalent, like "touchpad". A "crc"
+	// field is stored in the GUID instead.
+	Extra map[string]string
+}
+
+// sdlOutputs maps SDL control names to logical controls.
+var sdlOutputs = map[string]struct {
+	isAxis bool
+	button Button
+	axis   Axis
+}{
+	"a":             {button: ButtonA},
+	"b":             {button: ButtonB},
+	"x":             {button: ButtonX},
+	"y":             {button: ButtonY},
+	"back":          {button: ButtonBack},
+	"guide":         {button: ButtonGuide},
+	"start":         {button: ButtonStart},
+	"leftstick":     {button: ButtonLeftStick},
+	"rightstick":    {button: ButtonRightStick},
+	"leftshoulder":  {button: ButtonLeftBumper},
+	"rightshoulder": {button: ButtonRightBumper},
+	"dpup":          {button: ButtonDpadUp},
+	"dpdown":        {button: ButtonDpadDown},
+	"dpleft":        {button: ButtonDpadLeft},
+	"dpright":       {button: ButtonDpadRight},
+	"misc1":         {button: ButtonShare},
+	"paddle1":       {button: ButtonPaddle1},
+	"paddle2":       {button: ButtonPaddle2},
+	"paddle3":       {button: ButtonPaddle3},
+	"paddle4":       {button: ButtonPaddle4},
+	"leftx":         {isAxis: true, axis: AxisLeftX},
+	"lefty":         {isAxis: true, axis: AxisLeftY},
+	"rightx":        {isAxis: true, axis: AxisRightX},
+	"righty":        {isAxis: true, axis: AxisRightY},
+	"lefttrigger":   {isAxis: true, axis: AxisLeftTrigger},
+	"righttrigger":  {isAxis: true, axis: AxisRightTrigger},
+}
+
+// ParseSDLMapping parses an SDL mapping string such as
+// "030000005e0400008e02000010010000,Xbox 360 Controller,a:b0,...". Like
+// SDL_GameControllerAddMapping, it puts the name CRC from a "crc" field into
+// the GUID.
+func ParseSDLMapping(text string) (*SDLMapping, error) {
+	fields := strings.Split(strings.TrimSpace(text), ",")
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("xpad: SDL mapping %q: missing GUID or name", text)
+	}
+	guid, err := ParseSDLGUID(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	m := &SDLMapping{GUID: guid, Name: fields[1]}
+	for _, field := range fields[2:] {
+		if field == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(field, ":")
+		if !ok {
+			return nil, fmt.Errorf("xpad: SDL mapping %q: invalid field %q", m.Name, field)
+		}
+		switch key {
+		case "platform":
+			m.Platform = value
+			continue
+		case "crc":
+			crc, err := strconv.ParseUint(value, 16, 16)
+			if err != nil {
+				return nil, fmt.Errorf("xpad: SDL mapping %q: invalid crc %q", m.Name, value)
+			}
+			m.GUID = m.GUID.withCRC(uint16(crc))
+			continue
+		}
+		half := int8(0)
+		name := key
+		switch {
+		case strings.HasPrefix(name, "+"):
+			half, name = 1, name[1:]
+		case strings.HasPrefix(name, "-"):
+			half, name = -1, name[1:]
+		}
+		output, ok := sdlOutputs[name]
+		if !ok {
+			if m.Extra == nil {
+				m.Extra = make(map[string]string)
+			}
+			m.Extra[key] = value
+			continue
+		}
+		binding, err := parseSDLInput(value)
+		if err != nil {
+			return nil, fmt.Errorf("xpad: SDL mapping %q: %s: %w", m.Name, key, err)
+		}
+		binding.IsAxis, binding.Button, binding.Axis = output.isAxis, output.button, output.axis
+		if output.isAxis {
+			binding.OutputHalf = half
+		}
+		m.Bindings = append(m.Bindings, binding)
+	}
+	return m, nil
+}
+
+// parseSDLInput parses the input side of a binding: "b3", "a2", "+a2",
+// "a2~" or "h0.4".
+func parseSDLInput(text string) (SDLBinding, error) {
+	var b SDLBinding
+	switch {
+	case strings.HasPrefix(text, "+"):
+		b.InputHalf, text = 1, text[1:]
+	case strings.HasPrefix(text, "-"):
+		b.InputHalf, text = -1, text[1:]
+	}
+	if strings.HasSuffix(text, "~") {
+		b.Invert, text = true, strings.TrimSuffix(text, "~")
+	}
+	if text == "" {
+		return b, fmt.Errorf("empty input")
+	}
+	number := text[1:]
+	switch text[0] {
+	case 'b':
+		b.Input = SDLInputButton
+	case 'a':
+		b.Input = SDLInputAxis
+	case 'h':
+		b.Input = SDLInputHat
+		hat, mask, ok := strings.Cut(number, ".")
+		if !ok {
+			return b, fmt.Errorf("invalid hat %q", text)
+		}
+		m, err := strconv.ParseUint(mask, 10, 4)
+		if err != nil {
+			return b, fmt.Errorf("invalid hat %q", text)
+		}
+		b.HatMask, number = uint8(m), hat
+	default:
+		return b, fmt.Errorf("invalid input %q", text)
+	}
+	index, err := strconv.Atoi(number)
+	if err != nil || index < 0 {
+		return b, fmt.Errorf("invalid input %q", text)
+	}
+	b.Index = index
+	if b.Input != SDLInputAxis && (b.InputHalf != 0 || b.Invert) {
+		return b, fmt.Errorf("invalid input %q: only axes take a range", text)
+	}
+	return b, nil
+}
+
+// SDLControllerDB is a set of SDL mappings, as in gamecontrollerdb.txt.
+type SDLControllerDB struct {
+	mappings []*SDLMapping
+	skipped  []error
+}
+
+// ParseSDLControllerDB reads mapping strings, one per line. Blank lines and
+// lines starting with '#' are skipped. Like SDL, it also skips invalid lines
+// and keeps going; Skipped reports them. The error is for read failures only.
+func ParseSDLControllerDB(r io.Reader) (*SDLControllerDB, error) {
+	db := &SDLControllerDB{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		m, err := ParseSDLMapping(text)
+		if err != nil {
+			db.skipped = append(db.skipped, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+		db.Add(m)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// Skipped returns the errors for the invalid lines ParseSDLControllerDB
+// skipped, in file order.
+func (db *SDLControllerDB) Skipped() []error {
+	return append([]error(nil), db.skipped...)
+}
+
+// Add adds a mapping. Like SDL_GameControllerAddMapping, a later mapping for
+// the same GUID, including its name CRC, and platform replaces an earlier
+// one.
+func (db *SDLControllerDB) Add(m *SDLMapping) {
+	for i, old := range db.mappings {
+		if old.GUID == m.GUID && old.Platform == m.Platform {
+			db.mappings[i] = m
+			return
+		}
+	}
+	db.mappings = append(db.mappings, m)
+}
+
+// Mappings returns the mappings in the order they were added.
+func (db *SDLControllerDB) Mappings() []*SDLMapping {
+	return append([]*SDLMapping(nil), db.mappings...)
+}
+
+// Lookup returns the Linux mapping for a GUID. Like SDL it ignores the name
+// CRC unless both sides have one, and falls back to a mapping without a
+// version.
+func (db *SDLControllerDB) Lookup(guid SDLGUID) (*SDLMapping, bool) {
+	if m, ok := db.lookup(guid); ok {
+		return m, true
+	}
+	if guid.hasIDs() {
+		return db.lookup(guid.withoutVersion())
+	}
+	return nil, false
+}
+
+func (db *SDLControllerDB) lookup(guid SDLGUID) (*SDLMapping, bool) {
+	for _, m := range db.mappings {
+		if m.Platform != "" && m.Platform != "Linux" {
+			continue
+		}
+		if m.GUID.withCRC(0) != guid.withCRC(0) {
+			continue
+		}
+		if m.GUID.CRC() != 0 && guid.CRC() != 0 && m.GUID.CRC() != guid.CRC() {
+			continue
+		}
+		return m, true
+	}
+	return nil, false
+}
@@ -0,0 +1,41 @@
+//go:build linux
+
+package xpad
+
+import "fmt"
+
+// SDLGUID returns the GUID SDL computes for the device from its input ID and
+// name.
+func (d *Device) SDLGUID() (SDLGUID, error) {
+	id, err := d.ID()
+	if err != nil {
+		return SDLGUID{}, err
+	}
+	name, err := d.Name()
+	if err != nil {
+		return SDLGUID{}, err
+	}
+	return NewSDLGUID(id, name), nil
+}
+
+// SDLMapper looks the device up in db and returns a mapper for its events.
+// The error wraps ErrNotFound if db has no mapping for the device.
+func (d *Device) SDLMapper(db *SDLControllerDB) (*SDLMapper, error) {
+	guid, err := d.SDLGUID()
+	if err != nil {
+		return nil, err
+	}
+	mapping, ok := db.Lookup(guid)
+	if !ok {
+		return nil, fmt.Errorf("xpad: no SDL mapping for GUID %s: %w", guid, ErrNotFound)
+	}
+	caps, err := d.capabilities(EVKey, EVAbs)
+	if err != nil {
+		return nil, err
+	}
+	infos, err := d.absInfos(caps)
+	if err != nil {
+		return nil, err
+	}
+	return NewSDLMapper(mapping, caps, infos), nil
+}
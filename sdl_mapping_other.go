@@ -0,0 +1,13 @@
+//go:build !linux
+
+package xpad
+
+// SDLGUID is not supported on non-Linux platforms.
+func (d *Device) SDLGUID() (SDLGUID, error) {
+	return SDLGUID{}, ErrNotImplemented
+}
+
+// SDLMapper is not supported on non-Linux platforms.
+func (d *Device) SDLMapper(db *SDLControllerDB) (*SDLMapper, error) {
+	return nil, ErrNotImplemented
+}
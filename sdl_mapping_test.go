@@ -0,0 +1,262 @@
+package xpad
+
+import (
+	"math"
+	"os"
+	"strings"
+	"testing"
+)
+
+func loadSDLControllerDB(t *testing.T) *SDLControllerDB {
+	t.Helper()
+	f, err := os.Open("testdata/sdl/gamecontrollerdb.txt")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+	db, err := ParseSDLControllerDB(f)
+	if err != nil {
+		t.Fatalf("ParseSDLControllerDB error: %v", err)
+	}
+	// The fixture has one invalid line, which is skipped.
+	if skipped := db.Skipped(); len(skipped) != 1 || !strings.Contains(skipped[0].Error(), "Broken Pad") {
+		t.Fatalf("Skipped() = %v, want the Broken Pad line", skipped)
+	}
+	return db
+}
+
+func TestNewSDLGUID(t *testing.T) {
+	if got := sdlCRC16([]byte("123456789")); got != 0xbb3d {
+		t.Fatalf("sdlCRC16 check value = %#x, want 0xbb3d", got)
+	}
+	cases := []struct {
+		id   InputID
+		name string
+		want string
+	}{
+		{InputID{BusType: BusUSB, Vendor: 0x045e, Product: 0x028e, Version: 0x0114}, "Microsoft X-Box 360 pad", "030081b85e0400008e02000014010000"},
+		{InputID{BusType: BusVirtual}, "Virtual Gamepad Deluxe", "0600ce165669727475616c2047616d00"},
+	}
+	for _, tc := range cases {
+		guid := NewSDLGUID(tc.id, tc.name)
+		if guid.String() != tc.want {
+			t.Fatalf("NewSDLGUID(%+v, %q) = %s, want %s", tc.id, tc.name, guid, tc.want)
+		}
+		parsed, err := ParseSDLGUID(tc.want)
+		if err != nil || parsed != guid {
+			t.Fatalf("ParseSDLGUID(%s) = %s, %v", tc.want, parsed, err)
+		}
+	}
+}
+
+func TestSDLControllerDBLookup(t *testing.T) {
+	db := loadSDLControllerDB(t)
+	// Entries differing only in the crc field are both kept.
+	if got := len(db.Mappings()); got != 7 {
+		t.Fatalf("len(Mappings) = %d, want 7", got)
+	}
+	cases := []struct {
+		guid string
+		want string
+	}{
+		// Exact match, ignoring the CRC the DB entry lacks.
+		{"030081b85e0400008e02000010010000", "Xbox 360 Controller"},
+		// Unknown revision falls back to the versionless entry.
+		{"030081b85e0400008e02000014010000", "Xbox 360 Controller (any revision)"},
+		{"030000005e040000120b000009050000", "Xbox Series Controller"},
+		{"03000000341200007856000001000000", "Half Axis Test Pad"},
+		{"03003412341200007856000001000000", "Half Axis Test Pad"},
+		{"0300cdab341200007856000001000000", "Half Axis Test Pad (other name)"},
+		// A crc entry does not match a device with another name CRC.
+		{"03001111341200007856000001000000", ""},
+		{"030000005e040000ff02000000000000", ""},
+	}
+	for _, tc := range cases {
+		guid, err := ParseSDLGUID(tc.guid)
+		if err != nil {
+			t.Fatalf("ParseSDLGUID(%s) error: %v", tc.guid, err)
+		}
+		m, ok := db.Lookup(guid)
+		if tc.want == "" {
+			if ok {
+				t.Fatalf("Lookup(%s) = %q, want no mapping", tc.guid, m.Name)
+			}
+			continue
+		}
+		if !ok || m.Name != tc.want || m.Platform != "Linux" {
+			t.Fatalf("Lookup(%s) = %v, %v, want %q", tc.guid, m, ok, tc.want)
+		}
+	}
+}
+
+func TestParseSDLMapping(t *testing.T) {
+	m, err := ParseSDLMapping("03000000341200007856000001000000,Half Axis Test Pad,dpup:-a1,-leftx:b1,righttrigger:a0~,dpdown:h1.4,touchpad:b3,crc:1234,platform:Linux,")
+	if err != nil {
+		t.Fatalf("ParseSDLMapping error: %v", err)
+	}
+	want := []SDLBinding{
+		{Button: ButtonDpadUp, Input: SDLInputAxis, Index: 1, InputHalf: -1},
+		{IsAxis: true, Axis: AxisLeftX, OutputHalf: -1, Input: SDLInputButton, Index: 1},
+		{IsAxis: true, Axis: AxisRightTrigger, Input: SDLInputAxis, Index: 0, Invert: true},
+		{Button: ButtonDpadDown, Input: SDLInputHat, Index: 1, HatMask: 4},
+	}
+	if len(m.Bindings) != len(want) {
+		t.Fatalf("Bindings = %+v, want %+v", m.Bindings, want)
+	}
+	for i := range want {
+		if m.Bindings[i] != want[i] {
+			t.Fatalf("Bindings[%d] = %+v, want %+v", i, m.Bindings[i], want[i])
+		}
+	}
+	if m.Extra["touchpad"] != "b3" || len(m.Extra) != 1 || m.Platform != "Linux" {
+		t.Fatalf("Extra, Platform = %v, %q", m.Extra, m.Platform)
+	}
+	if m.GUID.CRC() != 0x1234 {
+		t.Fatalf("GUID = %s, want CRC 0x1234 from the crc field", m.GUID)
+	}
+
+	for _, text := range []string{
+		"030000005e0400008e02000010010000",
+		"xinput,XInput Controller,a:b0,",
+		"030000005e0400008e02000010010000,Pad,a",
+		"030000005e0400008e02000010010000,Pad,a:c0",
+		"030000005e0400008e02000010010000,Pad,a:+b0",
+		"030000005e0400008e02000010010000,Pad,dpup:h0",
+		"030000005e0400008e02000010010000,Pad,crc:xyz",
+	} {
+		if _, err := ParseSDLMapping(text); err == nil {
+			t.Fatalf("ParseSDLMapping(%q) error = nil", text)
+		}
+	}
+}
+
+func TestSDLMapper(t *testing.T) {
+	db := loadSDLControllerDB(t)
+	stick := AbsInfo{Minimum: -32768, Maximum: 32767}
+	trigger := AbsInfo{Maximum: 255}
+	pad := []uint16{BTNA, BTNB, BTNX, BTNY, BTNTL, BTNTR, BTNSelect, BTNStart, BTNMode, BTNThumbL, BTNThumbR}
+	absInfo := map[uint16]AbsInfo{
+		ABSX: stick, ABSY: stick, ABSZ: trigger, ABSRX: stick, ABSRY: stick, ABSRZ: trigger,
+		ABSHat0X: {Minimum: -1, Maximum: 1}, ABSHat0Y: {Minimum: -1, Maximum: 1},
+	}
+	key := func(code uint16, value int32) Event { return Event{Kind: EVKey, Code: code, Value: value} }
+	abs := func(code uint16, value int32) Event { return Event{Kind: EVAbs, Code: code, Value: value} }
+	button := func(b Button, pressed bool) ControlEvent { return ControlEvent{Button: b, Pressed: pressed} }
+	axis := func(a Axis, value float32) ControlEvent { return ControlEvent{IsAxis: true, Axis: a, Value: value} }
+
+	type step struct {
+		ev   Event
+		want []ControlEvent
+	}
+	cases := []struct {
+		name  string
+		guid  string
+		keys  []uint16
+		abs   map[uint16]AbsInfo
+		steps []step
+	}{
+		{
+			name: "xbox 360",
+			guid: "030000005e0400008e02000010010000",
+			keys: pad,
+			abs:  absInfo,
+			steps: []step{
+				{key(BTNSelect, KeyPressed), []ControlEvent{button(ButtonBack, true)}},
+				{key(BTNMode, KeyPressed), []ControlEvent{button(ButtonGuide, true)}},
+				{key(BTNMode, KeyRepeated), nil},
+				{key(BTNThumbR, KeyPressed), []ControlEvent{button(ButtonRightStick, true)}},
+				{abs(ABSZ, 255), []ControlEvent{axis(AxisLeftTrigger, 1)}},
+				{abs(ABSZ, 0), []ControlEvent{axis(AxisLeftTrigger, 0)}},
+				{abs(ABSRX, 32767), []ControlEvent{axis(AxisRightX, 1)}},
+				{abs(ABSHat0X, -1), []ControlEvent{button(ButtonDpadLeft, true)}},
+				{abs(ABSHat0Y, -1), []ControlEvent{button(ButtonDpadUp, true)}},
+				{abs(ABSHat0X, 1), []ControlEvent{button(ButtonDpadLeft, false), button(ButtonDpadRight, true)}},
+				{abs(ABSHat0Y, 0), []ControlEvent{button(ButtonDpadUp, false)}},
+				{abs(ABSProfile, 1), nil},
+			},
+		},
+		{
+			name: "series share",
+			guid: "030000005e040000120b000009050000",
+			keys: append([]uint16{KeyRecord}, pad...),
+			abs:  absInfo,
+			steps: []step{
+				{key(KeyRecord, KeyPressed), []ControlEvent{button(ButtonShare, true)}},
+				{key(BTNY, KeyPressed), []ControlEvent{button(ButtonY, true)}},
+			},
+		},
+		{
+			name: "dpad to buttons",
+			guid: "030000005e040000a102000000010000",
+			keys: append(append([]uint16(nil), pad...), BTNTriggerHappy1, BTNTriggerHappy2, BTNTriggerHappy3, BTNTriggerHappy4),
+			abs:  map[uint16]AbsInfo{ABSX: stick, ABSY: stick, ABSZ: trigger, ABSRX: stick, ABSRY: stick, ABSRZ: trigger},
+			steps: []step{
+				{key(BTNTriggerHappy3, KeyPressed), []ControlEvent{button(ButtonDpadUp, true)}},
+				{key(BTNTriggerHappy1, KeyPressed), []ControlEvent{button(ButtonDpadLeft, true)}},
+				{key(BTNTriggerHappy3, KeyReleased), []ControlEvent{button(ButtonDpadUp, false)}},
+			},
+		},
+		{
+			name: "half axes",
+			guid: "03000000341200007856000001000000",
+			keys: []uint16{BTNA, BTNB, BTNX, BTNY},
+			abs:  map[uint16]AbsInfo{ABSX: stick, ABSY: stick, ABSZ: stick},
+			steps: []step{
+				{abs(ABSY, -32768), []ControlEvent{button(ButtonDpadUp, true)}},
+				{abs(ABSY, 32767), []ControlEvent{button(ButtonDpadUp, false), button(ButtonDpadDown, true)}},
+				{key(BTNB, KeyPressed), []ControlEvent{axis(AxisLeftX, -1)}},
+				{key(BTNB, KeyReleased), []ControlEvent{axis(AxisLeftX, 0)}},
+				{key(BTNX, KeyPressed), []ControlEvent{axis(AxisLeftX, 1)}},
+				{abs(ABSZ, 32767), []ControlEvent{axis(AxisLeftTrigger, 1)}},
+				{abs(ABSZ, -32768), []ControlEvent{axis(AxisLeftTrigger, 0)}},
+				{abs(ABSX, -32768), []ControlEvent{axis(AxisRightTrigger, 1)}},
+				{key(BTNY, KeyPressed), nil},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			guid, err := ParseSDLGUID(tc.guid)
+			if err != nil {
+				t.Fatalf("ParseSDLGUID error: %v", err)
+			}
+			mapping, ok := db.Lookup(guid)
+			if !ok {
+				t.Fatalf("Lookup(%s) found no mapping", tc.guid)
+			}
+			var caps Capabilities
+			for _, code := range tc.keys {
+				caps.setCode(EVKey, code)
+			}
+			for code := range tc.abs {
+				caps.setCode(EVAbs, code)
+			}
+			m := NewSDLMapper(mapping, caps, tc.abs)
+			for _, s := range tc.steps {
+				got := m.Apply(s.ev)
+				if len(got) != len(s.want) {
+					t.Fatalf("Apply(%+v) = %+v, want %+v", s.ev, got, s.want)
+				}
+				for i := range got {
+					if got[i] != s.want[i] {
+						t.Fatalf("Apply(%+v)[%d] = %+v, want %+v", s.ev, i, got[i], s.want[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSDLMapperIgnoresFlat(t *testing.T) {
+	mapping, err := ParseSDLMapping("03000000341200007856000001000000,Flat Pad,leftx:a0,platform:Linux,")
+	if err != nil {
+		t.Fatalf("ParseSDLMapping error: %v", err)
+	}
+	var caps Capabilities
+	caps.setCode(EVAbs, ABSX)
+	m := NewSDLMapper(mapping, caps, map[uint16]AbsInfo{ABSX: {Minimum: -100, Maximum: 100, Flat: 20}})
+	got := m.Apply(Event{Kind: EVAbs, Code: ABSX, Value: 10})
+	if len(got) != 1 || math.Abs(float64(got[0].Value-0.1)) > 0.001 {
+		t.Fatalf("Apply(ABSX 10) = %+v, want leftx 0.1", got)
+	}
+}
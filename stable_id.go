@@ -0,0 +1,45 @@
+package xpad
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// StableID returns an identifier that survives replugging and reboots, unlike
+// Path. It combines the vendor and product IDs with the most specific
+// identity available: Uniq (e.g. a Bluetooth address), the USB serial number,
+// the USB port path, or Phys. USB IDs also carry the interface number so the
+// pads behind a wireless receiver stay distinct.
+//
+// IDs look like "045e:028e/serial:0843E1B/if0". Devices without a serial keep
+// their ID only while they stay on the same port.
+func (d DeviceInfo) StableID() string {
+	var key string
+	switch {
+	case d.Uniq != "":
+		key = "uniq:" + d.Uniq
+	case d.USB.Serial != "":
+		key = "serial:" + d.USB.Serial
+	case d.USB.PortPath != "":
+		key = "port:" + d.USB.PortPath
+	case d.Phys != "":
+		key = "phys:" + d.Phys
+	default:
+		return ""
+	}
+	id := fmt.Sprintf("%04x:%04x/%s", d.VendorID, d.ProductID, sanitizeStableID(key))
+	if d.USB.InterfaceNumber >= 0 && d.USB.SysfsPath != "" {
+		id += fmt.Sprintf("/if%d", d.USB.InterfaceNumber)
+	}
+	return id
+}
+
+func sanitizeStableID(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || !unicode.IsPrint(r) || unicode.IsSpace(r) {
+			return '_'
+		}
+		return r
+	}, text)
+}
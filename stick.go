@@ -0,0 +1,282 @@
+package xpad
+
+import "math"
+
+// DeadzoneMode selects how the inner deadzone of a stick is applied.
+type DeadzoneMode uint8
+
+const (
+	// DeadzoneNone applies no deadzone.
+	DeadzoneNone DeadzoneMode = iota
+	// DeadzoneAxial zeroes each axis independently; it snaps to the axes
+	// but makes diagonals feel square.
+	DeadzoneAxial
+	// DeadzoneRadial zeroes the stick inside a circle and passes the rest
+	// through unchanged, so output jumps at the deadzone edge.
+	DeadzoneRadial
+	// DeadzoneScaledRadial zeroes the stick inside a circle and rescales the
+	// remaining travel to start from 0.
+	DeadzoneScaledRadial
+	// DeadzoneHybrid is scaled radial followed by a sloped axial deadzone,
+	// which keeps smooth diagonals while easing onto the cardinal axes.
+	DeadzoneHybrid
+)
+
+// ResponseCurve maps an input magnitude in [0,1] to an output magnitude in
+// [0,1]. Curves should be monotonic with f(0) = 0 and f(1) = 1.
+type ResponseCurve interface {
+	Apply(x float32) float32
+}
+
+// LinearCurve is the identity response.
+type LinearCurve struct{}
+
+// Apply implements ResponseCurve.
+func (LinearCurve) Apply(x float32) float32 { return x }
+
+// PowerCurve raises the input to Exponent; values above 1 give finer control
+// near the center.
+type PowerCurve struct {
+	Exponent float32
+}
+
+// Apply implements ResponseCurve.
+func (c PowerCurve) Apply(x float32) float32 {
+	if c.Exponent <= 0 {
+		return x
+	}
+	return float32(math.Pow(float64(x), float64(c.Exponent)))
+}
+
+// BezierCurve is a cubic Bézier from (0,0) to (1,1) with control points
+// (X1,Y1) and (X2,Y2), as in CSS cubic-bezier(). X1 and X2 must be in [0,1].
+type BezierCurve struct {
+	X1, Y1, X2, Y2 float32
+}
+
+// Apply implements ResponseCurve.
+func (c BezierCurve) Apply(x float32) float32 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	bezier := func(t, p1, p2 float64) float64 {
+		u := 1 - t
+		return 3*u*u*t*p1 + 3*u*t*t*p2 + t*t*t
+	}
+	// x(t) is monotonic for X1, X2 in [0,1]; solve it by bisection.
+	lo, hi := 0.0, 1.0
+	for i := 0; i < 32; i++ {
+		mid := (lo + hi) / 2
+		if bezier(mid, float64(c.X1), float64(c.X2)) < float64(x) {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return float32(bezier((lo+hi)/2, float64(c.Y1), float64(c.Y2)))
+}
+
+// LookupCurve interpolates linearly between Points, which are outputs for
+// evenly spaced inputs from 0 to 1. It needs at least two points.
+type LookupCurve struct {
+	Points []float32
+}
+
+// Apply implements ResponseCurve.
+func (c LookupCurve) Apply(x float32) float32 {
+	n := len(c.Points)
+	if n < 2 {
+		return x
+	}
+	pos := float64(clamp(float64(x), 0, 1)) * float64(n-1)
+	i := int(pos)
+	if i >= n-1 {
+		return c.Points[n-1]
+	}
+	frac := float32(pos - float64(i))
+	return c.Points[i] + (c.Points[i+1]-c.Points[i])*frac
+}
+
+// StickConfig configures the processing of one two-axis stick. The zero
+// value passes input through unchanged.
+type StickConfig struct {
+	Mode DeadzoneMode
+	// Deadzone is the inner deadzone as a fraction of full travel.
+	Deadzone float32
+	// AntiDeadzone is the smallest output magnitude outside the deadzone, to
+	// cancel a game's own deadzone.
+	AntiDeadzone float32
+	// OuterSaturation is the input magnitude that already reads as full
+	// deflection; 0 means 1.
+	OuterSaturation float32
+	// Curve shapes the magnitude after the deadzone; nil means linear.
+	Curve ResponseCurve
+}
+
+// Apply processes a normalized stick position with x and y in [-1,1]. It is
+// a pure function of its inputs.
+func (c StickConfig) Apply(x, y float32) (float32, float32) {
+	saturation := c.OuterSaturation
+	if saturation <= 0 || saturation > 1 {
+		saturation = 1
+	}
+	deadzone := clamp32(c.Deadzone, 0, saturation)
+
+	switch c.Mode {
+	case DeadzoneAxial:
+		return c.axial(x, deadzone, saturation), c.axial(y, deadzone, saturation)
+	case DeadzoneRadial:
+		magnitude := hypot32(x, y)
+		if magnitude < deadzone || magnitude == 0 {
+			return 0, 0
+		}
+		return rescale(x, y, magnitude, c.shape(clamp32(magnitude/saturation, 0, 1)))
+	case DeadzoneScaledRadial, DeadzoneHybrid:
+		magnitude := hypot32(x, y)
+		if magnitude <= deadzone || magnitude == 0 {
+			return 0, 0
+		}
+		scaled := clamp32((magnitude-deadzone)/(saturation-deadzone), 0, 1)
+		outX, outY := rescale(x, y, magnitude, c.shape(scaled))
+		if c.Mode == DeadzoneHybrid {
+			outX, outY = slopedAxial(outX, outY, deadzone)
+		}
+		return outX, outY
+	default:
+		magnitude := hypot32(x, y)
+		if magnitude == 0 {
+			return 0, 0
+		}
+		return rescale(x, y, magnitude, c.shape(clamp32(magnitude/saturation, 0, 1)))
+	}
+}
+
+// axial applies the deadzone, saturation, curve and anti-deadzone to one axis.
+func (c StickConfig) axial(v, deadzone, saturation float32) float32 {
+	magnitude := abs32(v)
+	if magnitude <= deadzone {
+		return 0
+	}
+	out := c.shape(clamp32((magnitude-deadzone)/(saturation-deadzone), 0, 1))
+	if v < 0 {
+		return -out
+	}
+	return out
+}
+
+// shape applies the response curve and the anti-deadzone to a magnitude in
+// (0,1].
+func (c StickConfig) shape(magnitude float32) float32 {
+	if c.Curve != nil {
+		magnitude = clamp32(c.Curve.Apply(magnitude), 0, 1)
+	}
+	if c.AntiDeadzone > 0 {
+		magnitude = c.AntiDeadzone + (1-c.AntiDeadzone)*magnitude
+	}
+	return magnitude
+}
+
+// rescale points (x, y) in the same direction with a new magnitude.
+func rescale(x, y, magnitude, out float32) (float32, float32) {
+	return x / magnitude * out, y / magnitude * out
+}
+
+// slopedAxial zeroes each axis inside a deadzone that grows with the other
+// axis, easing motion near a cardinal direction onto it.
+func slopedAxial(x, y, deadzone float32) (float32, float32) {
+	sloped := func(v, other float32) float32 {
+		zone := deadzone * abs32(other)
+		if abs32(v) <= zone {
+			return 0
+		}
+		out := (abs32(v) - zone) / (1 - zone)
+		if v < 0 {
+			return -out
+		}
+		return out
+	}
+	return sloped(x, y), sloped(y, x)
+}
+
+func hypot32(x, y float32) float32 {
+	return float32(math.Hypot(float64(x), float64(y)))
+}
+
+func abs32(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func clamp32(v, lo, hi float32) float32 {
+	return float32(clamp(float64(v), float64(lo), float64(hi)))
+}
+
+// Stick identifies a two-axis analog stick.
+type Stick uint8
+
+const (
+	// StickLeft is ABSX/ABSY.
+	StickLeft Stick = iota
+	// StickRight is ABSRX/ABSRY.
+	StickRight
+)
+
+// Axes returns the ABS_* codes of a stick's X and Y axes.
+func (s Stick) Axes() (x, y uint16) {
+	if s == StickRight {
+		return ABSRX, ABSRY
+	}
+	return ABSX, ABSY
+}
+
+// StickProcessor applies per-stick deadzones and curves to normalized axis
+// values, e.g. from Normalizer.Event. It remembers the last value of each
+// axis so a change on one axis is processed together with the other.
+type StickProcessor struct {
+	Left, Right StickConfig
+
+	position [2][2]float32
+}
+
+// Update records a normalized value for an ABS_* code. For stick axes it
+// returns the stick and its processed position; ok is false for other codes.
+func (p *StickProcessor) Update(code uint16, value float32) (stick Stick, x, y float32, ok bool) {
+	var axis int
+	switch code {
+	case ABSX:
+		stick, axis = StickLeft, 0
+	case ABSY:
+		stick, axis = StickLeft, 1
+	case ABSRX:
+		stick, axis = StickRight, 0
+	case ABSRY:
+		stick, axis = StickRight, 1
+	default:
+		return 0, 0, 0, false
+	}
+	p.position[stick][axis] = value
+	config := p.Left
+	if stick == StickRight {
+		config = p.Right
+	}
+	x, y = config.Apply(p.position[stick][0], p.position[stick][1])
+	return stick, x, y, true
+}
+
+// NewLookupCurve builds a LookupCurve with n evenly spaced samples of f. It
+// is a convenience for precomputing expensive curves.
+func NewLookupCurve(n int, f func(x float32) float32) LookupCurve {
+	if n < 2 {
+		n = 2
+	}
+	points := make([]float32, n)
+	for i := range points {
+		points[i] = f(float32(i) / float32(n-1))
+	}
+	return LookupCurve{Points: points}
+}
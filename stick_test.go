@@ -0,0 +1,105 @@
+package xpad
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStickConfigApply(t *testing.T) {
+	type point struct{ x, y float32 }
+	// grid covers the center, the deadzone edge, a cardinal, a diagonal and
+	// a point past the outer saturation.
+	grid := []point{{0, 0}, {0.1, 0.1}, {0.25, 0}, {0.6, 0}, {0.5, 0.5}, {0.05, 0.9}, {1, 0}}
+	cases := []struct {
+		name   string
+		config StickConfig
+		want   []point
+	}{
+		{
+			name:   "passthrough",
+			config: StickConfig{},
+			want:   []point{{0, 0}, {0.1, 0.1}, {0.25, 0}, {0.6, 0}, {0.5, 0.5}, {0.05, 0.9}, {1, 0}},
+		},
+		{
+			name:   "axial",
+			config: StickConfig{Mode: DeadzoneAxial, Deadzone: 0.2},
+			want:   []point{{0, 0}, {0, 0}, {0.0625, 0}, {0.5, 0}, {0.375, 0.375}, {0, 0.875}, {1, 0}},
+		},
+		{
+			name:   "radial",
+			config: StickConfig{Mode: DeadzoneRadial, Deadzone: 0.2},
+			want:   []point{{0, 0}, {0, 0}, {0.25, 0}, {0.6, 0}, {0.5, 0.5}, {0.05, 0.9}, {1, 0}},
+		},
+		{
+			name:   "scaled radial with saturation",
+			config: StickConfig{Mode: DeadzoneScaledRadial, Deadzone: 0.2, OuterSaturation: 0.8},
+			want:   []point{{0, 0}, {0, 0}, {0.0833, 0}, {0.6667, 0}, {0.5976, 0.5976}, {0.0555, 0.9985}, {1, 0}},
+		},
+		{
+			name:   "hybrid",
+			config: StickConfig{Mode: DeadzoneHybrid, Deadzone: 0.2},
+			want:   []point{{0, 0}, {0, 0}, {0.0625, 0}, {0.5, 0}, {0.3939, 0.3939}, {0, 0.8744}, {1, 0}},
+		},
+		{
+			name:   "anti-deadzone",
+			config: StickConfig{Mode: DeadzoneScaledRadial, Deadzone: 0.2, AntiDeadzone: 0.3},
+			want:   []point{{0, 0}, {0, 0}, {0.34375, 0}, {0.65, 0}, {0.5259, 0.5259}, {0.0507, 0.9123}, {1, 0}},
+		},
+		{
+			name:   "power curve",
+			config: StickConfig{Mode: DeadzoneScaledRadial, Curve: PowerCurve{Exponent: 2}},
+			want:   []point{{0, 0}, {0.0141, 0.0141}, {0.0625, 0}, {0.36, 0}, {0.3536, 0.3536}, {0.0451, 0.8112}, {1, 0}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i, in := range grid {
+				x, y := tc.config.Apply(in.x, in.y)
+				want := tc.want[i]
+				if math.Abs(float64(x-want.x)) > 0.001 || math.Abs(float64(y-want.y)) > 0.001 {
+					t.Fatalf("Apply(%v, %v) = (%.4f, %.4f), want (%v, %v)", in.x, in.y, x, y, want.x, want.y)
+				}
+			}
+		})
+	}
+}
+
+func TestResponseCurves(t *testing.T) {
+	inputs := []float32{0, 0.25, 0.5, 0.75, 1}
+	cases := []struct {
+		name  string
+		curve ResponseCurve
+		want  []float32
+	}{
+		{"linear", LinearCurve{}, []float32{0, 0.25, 0.5, 0.75, 1}},
+		{"power", PowerCurve{Exponent: 3}, []float32{0, 0.015625, 0.125, 0.421875, 1}},
+		{"bezier linear", BezierCurve{X1: 0.25, Y1: 0.25, X2: 0.75, Y2: 0.75}, []float32{0, 0.25, 0.5, 0.75, 1}},
+		{"bezier ease", BezierCurve{X1: 0.42, Y1: 0, X2: 0.58, Y2: 1}, []float32{0, 0.1291, 0.5, 0.8709, 1}},
+		{"lookup", LookupCurve{Points: []float32{0, 0.1, 1}}, []float32{0, 0.05, 0.1, 0.55, 1}},
+		{"sampled", NewLookupCurve(5, func(x float32) float32 { return x * x }), []float32{0, 0.0625, 0.25, 0.5625, 1}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for i, x := range inputs {
+				if got := tc.curve.Apply(x); math.Abs(float64(got-tc.want[i])) > 0.001 {
+					t.Fatalf("Apply(%v) = %.4f, want %v", x, got, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStickProcessor(t *testing.T) {
+	p := StickProcessor{Right: StickConfig{Mode: DeadzoneAxial, Deadzone: 0.5}}
+	if stick, x, y, ok := p.Update(ABSX, 0.4); !ok || stick != StickLeft || x != 0.4 || y != 0 {
+		t.Fatalf("Update(ABSX) = %v, %v, %v, %v", stick, x, y, ok)
+	}
+	p.Update(ABSRX, 0.75)
+	if stick, x, y, ok := p.Update(ABSRY, 0.25); !ok || stick != StickRight || x != 0.5 || y != 0 {
+		t.Fatalf("Update(ABSRY) = %v, %v, %v, %v, want right (0.5, 0)", stick, x, y, ok)
+	}
+	if _, _, _, ok := p.Update(ABSZ, 1); ok {
+		t.Fatalf("Update(ABSZ) ok = true, want false")
+	}
+}
@@ -0,0 +1,147 @@
+package xpad
+
+import "time"
+
+// defaultActivation and defaultHairSensitivity apply when the corresponding
+// TriggerConfig fields are zero.
+const (
+	defaultActivation      = 0.5
+	defaultHairSensitivity = 0.02
+)
+
+// TriggerConfig configures the processing of one analog trigger. It works in
+// userspace on normalized values, so unlike the triggers_to_buttons module
+// parameter it can differ per application and keeps the analog axis.
+type TriggerConfig struct {
+	// Curve remaps the trigger travel before the thresholds are applied; nil
+	// means linear.
+	Curve ResponseCurve
+	// Activation is the remapped value at which the digital button presses;
+	// 0 means 0.5.
+	Activation float32
+	// Hysteresis is how far below Activation the value must fall before the
+	// button releases, so noise around the activation point does not chatter.
+	Hysteresis float32
+	// HairTrigger presses the button on any increase and releases it on any
+	// decrease, ignoring Activation and Hysteresis.
+	HairTrigger bool
+	// HairSensitivity is the change in value a hair trigger reacts to; 0
+	// means 0.02.
+	HairSensitivity float32
+}
+
+// TriggerResult is the outcome of one trigger update.
+type TriggerResult struct {
+	// Code is the trigger axis, ABSZ or ABSRZ.
+	Code uint16
+	// Button is the synthesized button, BTNTL2 or BTNTR2.
+	Button uint16
+	// Value is the remapped analog value in [0,1].
+	Value float32
+	// Pressed is the digital state after the update.
+	Pressed bool
+	// Changed reports whether Pressed changed with this update.
+	Changed bool
+}
+
+// ButtonEvent returns the synthesized EV_KEY event for the digital state.
+func (r TriggerResult) ButtonEvent(when time.Time) Event {
+	value := int32(KeyReleased)
+	if r.Pressed {
+		value = KeyPressed
+	}
+	return Event{When: when, Kind: EVKey, Code: r.Button, Value: value}
+}
+
+// triggerState is the digital state of one trigger. anchor is the lowest
+// value since release or the highest since press, for hair triggers.
+type triggerState struct {
+	pressed bool
+	anchor  float32
+}
+
+// update applies the thresholds of c to a remapped value.
+func (s *triggerState) update(c TriggerConfig, value float32) {
+	if c.HairTrigger {
+		sensitivity := c.HairSensitivity
+		if sensitivity <= 0 {
+			sensitivity = defaultHairSensitivity
+		}
+		switch {
+		case value <= 0:
+			s.pressed, s.anchor = false, 0
+		case !s.pressed && value >= s.anchor+sensitivity:
+			s.pressed, s.anchor = true, value
+		case s.pressed && value <= s.anchor-sensitivity:
+			s.pressed, s.anchor = false, value
+		case s.pressed && value > s.anchor, !s.pressed && value < s.anchor:
+			s.anchor = value
+		}
+		return
+	}
+
+	activation := c.Activation
+	if activation <= 0 {
+		activation = defaultActivation
+	}
+	if s.pressed {
+		release := activation - c.Hysteresis
+		s.pressed = value > 0 && value >= release
+	} else {
+		s.pressed = value >= activation
+	}
+}
+
+// TriggerProcessor applies per-trigger curves and thresholds to normalized
+// trigger values, e.g. from Normalizer.Event, and synthesizes BTNTL2/BTNTR2
+// transitions from them.
+type TriggerProcessor struct {
+	Left, Right TriggerConfig
+
+	state [2]triggerState
+}
+
+// Update processes a normalized value for ABSZ (left) or ABSRZ (right). ok is
+// false for other codes.
+func (p *TriggerProcessor) Update(code uint16, value float32) (result TriggerResult, ok bool) {
+	config, index := p.Left, 0
+	switch code {
+	case ABSZ:
+		result.Button = BTNTL2
+	case ABSRZ:
+		config, index = p.Right, 1
+		result.Button = BTNTR2
+	default:
+		return TriggerResult{}, false
+	}
+	value = clamp32(value, 0, 1)
+	if config.Curve != nil {
+		value = clamp32(config.Curve.Apply(value), 0, 1)
+	}
+	state := &p.state[index]
+	was := state.pressed
+	state.update(config, value)
+
+	result.Code = code
+	result.Value = value
+	result.Pressed = state.pressed
+	result.Changed = state.pressed != was
+	return result, true
+}
+
+// Pressed reports the digital state of BTNTL2 or BTNTR2.
+func (p *TriggerProcessor) Pressed(button uint16) bool {
+	switch button {
+	case BTNTL2:
+		return p.state[0].pressed
+	case BTNTR2:
+		return p.state[1].pressed
+	default:
+		return false
+	}
+}
+
+// Reset releases both triggers.
+func (p *TriggerProcessor) Reset() {
+	p.state = [2]triggerState{}
+}
@@ -0,0 +1,81 @@
+package xpad
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestTriggerProcessor(t *testing.T) {
+	type step struct {
+		value   float32
+		pressed bool
+		changed bool
+	}
+	cases := []struct {
+		name   string
+		config TriggerConfig
+		steps  []step
+	}{
+		{
+			name:   "default activation",
+			config: TriggerConfig{},
+			steps:  []step{{0.2, false, false}, {0.5, true, true}, {0.49, false, true}, {0, false, false}},
+		},
+		{
+			name:   "hysteresis",
+			config: TriggerConfig{Activation: 0.4, Hysteresis: 0.1},
+			steps:  []step{{0.39, false, false}, {0.41, true, true}, {0.35, true, false}, {0.31, true, false}, {0.29, false, true}, {0.35, false, false}},
+		},
+		{
+			name:   "hair trigger",
+			config: TriggerConfig{HairTrigger: true, HairSensitivity: 0.05},
+			steps: []step{
+				{0.03, false, false}, {0.06, true, true}, {0.6, true, false},
+				{0.57, true, false}, {0.54, false, true}, {0.3, false, false},
+				{0.34, false, false}, {0.36, true, true}, {0, false, true},
+			},
+		},
+		{
+			name:   "curve before threshold",
+			config: TriggerConfig{Curve: PowerCurve{Exponent: 2}},
+			steps:  []step{{0.6, false, false}, {0.75, true, true}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := TriggerProcessor{Right: tc.config}
+			for _, s := range tc.steps {
+				result, ok := p.Update(ABSRZ, s.value)
+				if !ok || result.Button != BTNTR2 {
+					t.Fatalf("Update(ABSRZ, %v) = %+v, %v", s.value, result, ok)
+				}
+				if result.Pressed != s.pressed || result.Changed != s.changed {
+					t.Fatalf("Update(ABSRZ, %v) pressed, changed = %v, %v, want %v, %v", s.value, result.Pressed, result.Changed, s.pressed, s.changed)
+				}
+				if p.Pressed(BTNTR2) != s.pressed || p.Pressed(BTNTL2) {
+					t.Fatalf("Pressed after %v = %v/%v", s.value, p.Pressed(BTNTL2), p.Pressed(BTNTR2))
+				}
+			}
+		})
+	}
+}
+
+func TestTriggerResultEvents(t *testing.T) {
+	p := TriggerProcessor{Left: TriggerConfig{Curve: PowerCurve{Exponent: 2}}}
+	result, ok := p.Update(ABSZ, 0.8)
+	if !ok || math.Abs(float64(result.Value-0.64)) > 1e-6 {
+		t.Fatalf("Update(ABSZ, 0.8) = %+v, %v, want value 0.64", result, ok)
+	}
+	when := time.Unix(1, 0)
+	if ev := result.ButtonEvent(when); ev != (Event{When: when, Kind: EVKey, Code: BTNTL2, Value: KeyPressed}) {
+		t.Fatalf("ButtonEvent = %+v", ev)
+	}
+	if _, ok := p.Update(ABSX, 1); ok {
+		t.Fatalf("Update(ABSX) ok = true, want false")
+	}
+	p.Reset()
+	if p.Pressed(BTNTL2) {
+		t.Fatalf("Pressed(BTNTL2) after Reset = true")
+	}
+}
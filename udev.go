@@ -0,0 +1,81 @@
+package xpad
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+)
+
+// UdevData is a udev database record, as stored in /run/udev/data/c<major>:<minor>.
+// It is read directly, without libudev.
+type UdevData struct {
+	// Properties holds the E: lines, e.g. ID_INPUT_JOYSTICK=1.
+	Properties map[string]string
+	// Tags holds the G: lines, e.g. "uaccess" or "seat".
+	Tags []string
+	// CurrentTags holds the Q: lines written by systemd 247 and later.
+	CurrentTags []string
+	// Symlinks holds the S: lines, relative to /dev.
+	Symlinks []string
+}
+
+// ParseUdevData parses a udev database record. Unknown record types are
+// ignored.
+func ParseUdevData(r io.Reader) (UdevData, error) {
+	var data UdevData
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		tag, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || len(tag) != 1 {
+			continue
+		}
+		switch tag[0] {
+		case 'E':
+			key, val, _ := strings.Cut(value, "=")
+			if data.Properties == nil {
+				data.Properties = make(map[string]string)
+			}
+			data.Properties[key] = val
+		case 'G':
+			data.Tags = append(data.Tags, value)
+		case 'Q':
+			data.CurrentTags = append(data.CurrentTags, value)
+		case 'S':
+			data.Symlinks = append(data.Symlinks, value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return UdevData{}, err
+	}
+	return data, nil
+}
+
+// Property returns a udev property, or "" if unset.
+func (u UdevData) Property(key string) string {
+	return u.Properties[key]
+}
+
+// HasTag reports whether the record carries tag.
+func (u UdevData) HasTag(tag string) bool {
+	return slices.Contains(u.Tags, tag) || slices.Contains(u.CurrentTags, tag)
+}
+
+// IsJoystick reports whether udev classified the node as a joystick
+// (ID_INPUT_JOYSTICK).
+func (u UdevData) IsJoystick() bool {
+	return u.Property("ID_INPUT_JOYSTICK") == "1"
+}
+
+// Seat returns the seat assigned by ID_SEAT. Tagged devices without an
+// explicit seat belong to seat0, as in systemd-logind; untagged devices
+// return "".
+func (u UdevData) Seat() string {
+	if seat := u.Property("ID_SEAT"); seat != "" {
+		return seat
+	}
+	if u.HasTag("seat") {
+		return "seat0"
+	}
+	return ""
+}
@@ -0,0 +1,173 @@
+package xpad
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// DefaultModuleParamNames lists the xpad module parameters covered by
+// GenerateTmpfiles and GenerateUdevRules when PermissionConfig.ModuleParams
+// is nil.
+var DefaultModuleParamNames = []string{"dpad_to_buttons", "triggers_to_buttons", "sticks_to_null", "auto_poweroff"}
+
+// PermissionConfig describes the access granted by generated udev rules and
+// tmpfiles snippets.
+type PermissionConfig struct {
+	// Group owns the device nodes and sysfs files. When empty, device nodes
+	// are tagged "uaccess" so logind grants the active seat user access, and
+	// sysfs files, which uaccess cannot cover, fall back to the "input" group.
+	// It must not contain whitespace, quotes, commas or udev substitutions.
+	Group string
+	// NodeMode is the mode of event and js nodes; zero means 0660.
+	NodeMode os.FileMode
+	// SysfsMode is the mode of LED brightness and module parameter files; zero
+	// means 0664.
+	SysfsMode os.FileMode
+	// ModuleParams lists the parameter files to open up; nil means
+	// DefaultModuleParamNames.
+	ModuleParams []string
+}
+
+// validate rejects values that would break out of the generated rules.
+func (c PermissionConfig) validate() error {
+	if strings.ContainsFunc(c.Group, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r) || strings.ContainsRune(`"',%$`, r)
+	}) {
+		return fmt.Errorf("xpad: invalid group name %q", c.Group)
+	}
+	for _, name := range c.ModuleParams {
+		if !isParamName(name) {
+			return fmt.Errorf("xpad: invalid module parameter name %q", name)
+		}
+	}
+	return nil
+}
+
+func (c PermissionConfig) moduleParams() []string {
+	if c.ModuleParams == nil {
+		return DefaultModuleParamNames
+	}
+	return c.ModuleParams
+}
+
+func (c PermissionConfig) sysfsGroup() string {
+	if c.Group == "" {
+		return "input"
+	}
+	return c.Group
+}
+
+func (c PermissionConfig) nodeMode() os.FileMode {
+	if c.NodeMode == 0 {
+		return 0o660
+	}
+	return c.NodeMode
+}
+
+func (c PermissionConfig) sysfsMode() os.FileMode {
+	if c.SysfsMode == 0 {
+		return 0o664
+	}
+	return c.SysfsMode
+}
+
+// GenerateUdevRules returns a udev rules file granting access to the event
+// and js nodes and LED brightness files of devices, one block per
+// vendor/product pair, and to the module parameter files when xpad is loaded.
+// Install it as e.g. /etc/udev/rules.d/70-xpad.rules.
+func GenerateUdevRules(devices []DeviceInfo, cfg PermissionConfig) (string, error) {
+	if err := cfg.validate(); err != nil {
+		return "", err
+	}
+	type model struct {
+		vendor, product uint16
+		name            string
+		led             bool
+	}
+	models := make(map[uint32]*model)
+	for _, info := range devices {
+		key := uint32(info.VendorID)<<16 | uint32(info.ProductID)
+		m, ok := models[key]
+		if !ok {
+			m = &model{vendor: info.VendorID, product: info.ProductID, name: info.Name}
+			models[key] = m
+		}
+		if info.LEDPath != "" || (info.BusType == BusUSB && info.IsXpad()) {
+			m.led = true
+		}
+	}
+	keys := make([]uint32, 0, len(models))
+	for key := range models {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	var nodeAccess string
+	if cfg.Group == "" {
+		nodeAccess = fmt.Sprintf(`MODE="%04o", TAG+="uaccess"`, cfg.nodeMode())
+	} else {
+		nodeAccess = fmt.Sprintf(`GROUP="%s", MODE="%04o"`, cfg.Group, cfg.nodeMode())
+	}
+
+	var b strings.Builder
+	b.WriteString("# Generated by xpad-go: access to xpad controllers.\n")
+	b.WriteString("# Reload with: udevadm control --reload && udevadm trigger\n")
+	for _, key := range keys {
+		m := models[key]
+		if name := commentText(m.name); name != "" {
+			fmt.Fprintf(&b, "\n# %s (%04x:%04x)\n", name, m.vendor, m.product)
+		} else {
+			fmt.Fprintf(&b, "\n# %04x:%04x\n", m.vendor, m.product)
+		}
+		fmt.Fprintf(&b, `SUBSYSTEM=="input", KERNEL=="event*|js*", ATTRS{id/vendor}=="%04x", ATTRS{id/product}=="%04x", %s`+"\n",
+			m.vendor, m.product, nodeAccess)
+		if m.led {
+			fmt.Fprintf(&b, `SUBSYSTEM=="leds", KERNEL=="xpad*", ATTRS{idVendor}=="%04x", ATTRS{idProduct}=="%04x", RUN+="/bin/chgrp %s /sys%%p/brightness", RUN+="/bin/chmod %04o /sys%%p/brightness"`+"\n",
+				m.vendor, m.product, cfg.sysfsGroup(), cfg.sysfsMode())
+		}
+	}
+
+	// GenerateTmpfiles only covers a module loaded before tmpfiles runs at
+	// boot; this covers one loaded later, e.g. when a pad is plugged in.
+	if params := cfg.moduleParams(); len(params) > 0 {
+		b.WriteString("\n# xpad module parameters, when the module is loaded\n")
+		b.WriteString(`ACTION=="add", SUBSYSTEM=="module", KERNEL=="xpad"`)
+		for _, name := range params {
+			fmt.Fprintf(&b, `, RUN+="/bin/chgrp %s /sys%%p/parameters/%s", RUN+="/bin/chmod %04o /sys%%p/parameters/%s"`,
+				cfg.sysfsGroup(), name, cfg.sysfsMode(), name)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String(), nil
+}
+
+// GenerateTmpfiles returns a tmpfiles.d snippet that opens up the xpad module
+// parameter files at boot. Install it as e.g. /etc/tmpfiles.d/xpad.conf. It
+// only applies if xpad is already loaded when systemd-tmpfiles runs; the
+// rules from GenerateUdevRules cover a module loaded later.
+func GenerateTmpfiles(cfg PermissionConfig) (string, error) {
+	if err := cfg.validate(); err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	b.WriteString("# Generated by xpad-go: access to xpad module parameters.\n")
+	for _, name := range cfg.moduleParams() {
+		fmt.Fprintf(&b, "z /%s/%s %04o root %s - -\n", moduleParamDir, name, cfg.sysfsMode(), cfg.sysfsGroup())
+	}
+	return b.String(), nil
+}
+
+// commentText makes a device name safe for a rules file comment. Names come
+// from USB string descriptors, so control characters, which could end the
+// comment and start a rule, are replaced with spaces.
+func commentText(name string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, name))
+}
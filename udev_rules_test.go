@@ -0,0 +1,124 @@
+package xpad
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateUdevRules(t *testing.T) {
+	devices := []DeviceInfo{
+		{Name: "Microsoft X-Box One pad", BusType: BusUSB, VendorID: 0x045e, ProductID: 0x02ea},
+		{Name: "Microsoft X-Box 360 pad", BusType: BusUSB, VendorID: 0x045e, ProductID: 0x028e, LEDPath: "/sys/class/leds/xpad0"},
+		{Name: "Microsoft X-Box 360 pad", BusType: BusUSB, VendorID: 0x045e, ProductID: 0x028e, LEDPath: "/sys/class/leds/xpad1"},
+		{Name: "Xbox Wireless Controller", BusType: BusBluetooth, VendorID: 0x045e, ProductID: 0x0b13},
+	}
+	cases := []struct {
+		name    string
+		cfg     PermissionConfig
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "uaccess",
+			cfg:  PermissionConfig{},
+			want: []string{
+				`SUBSYSTEM=="input", KERNEL=="event*|js*", ATTRS{id/vendor}=="045e", ATTRS{id/product}=="028e", MODE="0660", TAG+="uaccess"`,
+				`SUBSYSTEM=="leds", KERNEL=="xpad*", ATTRS{idVendor}=="045e", ATTRS{idProduct}=="028e", RUN+="/bin/chgrp input /sys%p/brightness", RUN+="/bin/chmod 0664 /sys%p/brightness"`,
+				`ACTION=="add", SUBSYSTEM=="module", KERNEL=="xpad", RUN+="/bin/chgrp input /sys%p/parameters/dpad_to_buttons", RUN+="/bin/chmod 0664 /sys%p/parameters/dpad_to_buttons",`,
+				`RUN+="/bin/chmod 0664 /sys%p/parameters/auto_poweroff"` + "\n",
+			},
+			notWant: []string{`ATTRS{idProduct}=="0b13"`},
+		},
+		{
+			name: "group",
+			cfg:  PermissionConfig{Group: "games", NodeMode: 0o640, SysfsMode: 0o660},
+			want: []string{
+				`ATTRS{id/product}=="0b13", GROUP="games", MODE="0640"`,
+				`RUN+="/bin/chgrp games /sys%p/brightness", RUN+="/bin/chmod 0660 /sys%p/brightness"`,
+				`RUN+="/bin/chgrp games /sys%p/parameters/sticks_to_null"`,
+			},
+			notWant: []string{"uaccess"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rules, err := GenerateUdevRules(devices, tc.cfg)
+			if err != nil {
+				t.Fatalf("GenerateUdevRules() error: %v", err)
+			}
+			for _, want := range tc.want {
+				if !strings.Contains(rules, want) {
+					t.Fatalf("rules missing %q:\n%s", want, rules)
+				}
+			}
+			for _, unwanted := range tc.notWant {
+				if strings.Contains(rules, unwanted) {
+					t.Fatalf("rules contain %q:\n%s", unwanted, rules)
+				}
+			}
+			if n := strings.Count(rules, `ATTRS{id/product}=="028e"`); n != 1 {
+				t.Fatalf("028e input rules = %d, want 1", n)
+			}
+			if i, j := strings.Index(rules, "028e"), strings.Index(rules, "02ea"); i > j {
+				t.Fatalf("rules not ordered by vendor/product:\n%s", rules)
+			}
+		})
+	}
+}
+
+func TestGenerateTmpfiles(t *testing.T) {
+	got, err := GenerateTmpfiles(PermissionConfig{ModuleParams: []string{"dpad_to_buttons"}})
+	if err != nil {
+		t.Fatalf("GenerateTmpfiles() error: %v", err)
+	}
+	want := "z /sys/module/xpad/parameters/dpad_to_buttons 0664 root input - -\n"
+	if !strings.HasSuffix(got, want) || strings.Count(got, "\nz ") != 1 {
+		t.Fatalf("GenerateTmpfiles() = %q, want one line %q", got, want)
+	}
+}
+
+func TestGenerateUdevRulesSanitizesNames(t *testing.T) {
+	devices := []DeviceInfo{{
+		Name:      "Evil pad\nRUN+=\"/bin/sh -c id\"\r",
+		BusType:   BusUSB,
+		VendorID:  0x045e,
+		ProductID: 0x028e,
+	}}
+	rules, err := GenerateUdevRules(devices, PermissionConfig{})
+	if err != nil {
+		t.Fatalf("GenerateUdevRules() error: %v", err)
+	}
+	for _, line := range strings.Split(rules, "\n") {
+		if strings.HasPrefix(line, "RUN") || strings.Contains(line, "\r") {
+			t.Fatalf("GenerateUdevRules emitted injected line %q", line)
+		}
+	}
+	want := "# Evil pad RUN+=\"/bin/sh -c id\" (045e:028e)\n"
+	if !strings.Contains(rules, want) {
+		t.Fatalf("GenerateUdevRules() = %q, want comment %q", rules, want)
+	}
+}
+
+func TestGenerateRulesRejectsInvalidConfig(t *testing.T) {
+	devices := []DeviceInfo{{BusType: BusUSB, VendorID: 0x045e, ProductID: 0x028e}}
+	for _, cfg := range []PermissionConfig{
+		{Group: `games"`},
+		{Group: "games, MODE=\"0666\""},
+		{Group: "games\nRUN+=x"},
+		{Group: "my games"},
+		{Group: "%p"},
+		{ModuleParams: []string{"../../../etc/shadow"}},
+		{ModuleParams: []string{"dpad_to_buttons x"}},
+	} {
+		if _, err := GenerateUdevRules(devices, cfg); err == nil {
+			t.Fatalf("GenerateUdevRules(%+v) error = nil", cfg)
+		}
+		if _, err := GenerateTmpfiles(cfg); err == nil {
+			t.Fatalf("GenerateTmpfiles(%+v) error = nil", cfg)
+		}
+	}
+	if _, err := GenerateUdevRules(devices, PermissionConfig{Group: "game-pads_1"}); err != nil {
+		t.Fatalf("GenerateUdevRules(game-pads_1) error: %v", err)
+	}
+}
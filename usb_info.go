@@ -0,0 +1,23 @@
+package xpad
+
+// USBInfo describes the USB device and interface backing an input device.
+type USBInfo struct {
+	// SysfsPath is the sysfs directory of the USB device.
+	SysfsPath string
+	// BusNum and DevNum locate the device node under /dev/bus/usb.
+	BusNum int
+	DevNum int
+	// PortPath is the bus and port chain, e.g. "1-2.3" for port 3 of a hub
+	// on port 2 of bus 1. It stays the same while the cabling is unchanged.
+	PortPath string
+	// Speed is the link speed in Mbit/s as reported by sysfs, e.g. "12" or "480".
+	Speed string
+
+	Manufacturer string
+	Product      string
+	Serial       string
+	// BCDDevice is the device release number (bcdDevice).
+	BCDDevice uint16
+	// InterfaceNumber is the bInterfaceNumber bound by the driver, or -1.
+	InterfaceNumber int
+}
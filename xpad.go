@@ -7,18 +7,21 @@ import (
 )
 
 var (
-	ErrClosed         = errors.New("xpad: device is closed")
-	ErrNotImplemented = errors.New("xpad: not implemented")
-	ErrNotFound       = errors.New("xpad: no matching device found")
-	ErrReadOnly       = errors.New("xpad: device opened read-only")
-	ErrTimeout        = errors.New("xpad: read timeout")
+	ErrClosed             = errors.New("xpad: device is closed")
+	ErrNotImplemented     = errors.New("xpad: not implemented")
+	ErrNotFound           = errors.New("xpad: no matching device found")
+	ErrAmbiguous          = errors.New("xpad: more than one device matches")
+	ErrReadOnly           = errors.New("xpad: device opened read-only")
+	ErrTimeout            = errors.New("xpad: read timeout")
+	ErrInhibitUnsupported = errors.New("xpad: input inhibit not supported (requires Linux 5.11+)")
 )
 
 // Device represents an open xpad device.
 type Device struct {
-	Path     string
-	file     *os.File
-	readOnly bool
+	Path       string
+	file       *os.File
+	readOnly   bool
+	downgraded bool
 }
 
 // Event represents an input_event from the Linux input subsystem.
@@ -29,6 +32,21 @@ type Event struct {
 	Value int32
 }
 
+// IsPress reports whether ev is an initial key press (not an autorepeat).
+func (ev Event) IsPress() bool {
+	return ev.Kind == EVKey && ev.Value == KeyPressed
+}
+
+// IsRelease reports whether ev is a key release.
+func (ev Event) IsRelease() bool {
+	return ev.Kind == EVKey && ev.Value == KeyReleased
+}
+
+// IsRepeat reports whether ev is a kernel autorepeat of a held key.
+func (ev Event) IsRepeat() bool {
+	return ev.Kind == EVKey && ev.Value == KeyRepeated
+}
+
 // EventKind classifies an input event (EV_*).
 type EventKind uint16
 
@@ -38,11 +56,24 @@ const (
 
 // Open opens an xpad device by path.
 func Open(path string) (*Device, error) {
-	file, readOnly, err := openReadWriteOrReadOnly(path)
+	return OpenWithOptions(path, Options{})
+}
+
+// OpenWithOptions opens an xpad device by path using opts.
+//
+// Grab, mask and clock settings are applied before returning; if any of them
+// fails the device is closed and the error is returned.
+func OpenWithOptions(path string, opts Options) (*Device, error) {
+	file, readOnly, downgraded, err := openWithOptions(path, opts)
 	if err != nil {
 		return nil, err
 	}
-	return &Device{Path: path, file: file, readOnly: readOnly}, nil
+	d := &Device{Path: path, file: file, readOnly: readOnly, downgraded: downgraded}
+	if err := applyDeviceOptions(d, opts); err != nil {
+		d.Close()
+		return nil, err
+	}
+	return d, nil
 }
 
 // Close closes the device.
@@ -53,6 +84,7 @@ func (d *Device) Close() error {
 	err := d.file.Close()
 	d.file = nil
 	d.readOnly = false
+	d.downgraded = false
 	return err
 }
 
@@ -80,11 +112,25 @@ func (d *Device) ReadOnly() bool {
 	return d.readOnly
 }
 
+// Downgraded reports whether a read-write open failed and the handle fell
+// back to read-only.
+func (d *Device) Downgraded() bool {
+	if d == nil {
+		return false
+	}
+	return d.downgraded
+}
+
 // OpenDevice opens an xpad device from discovery info.
 func OpenDevice(info DeviceInfo) (*Device, error) {
 	return Open(info.Path)
 }
 
+// OpenDeviceWithOptions opens an xpad device from discovery info using opts.
+func OpenDeviceWithOptions(info DeviceInfo, opts Options) (*Device, error) {
+	return OpenWithOptions(info.Path, opts)
+}
+
 // ReadEvent blocks until the next event or timeout.
 func (d *Device) ReadEvent(timeout time.Duration) (Event, error) {
 	if d == nil || d.file == nil {
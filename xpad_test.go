@@ -18,6 +18,21 @@ func TestDeviceInfoIsXpad(t *testing.T) {
 			info: DeviceInfo{Driver: "hid-xpad"},
 			want: true,
 		},
+		{
+			name: "known IDs, other driver",
+			info: DeviceInfo{VendorID: 0x045e, ProductID: 0x028e, Driver: "hid-generic"},
+			want: false,
+		},
+		{
+			name: "known IDs, xpadneo",
+			info: DeviceInfo{VendorID: 0x045e, ProductID: 0x0b12, Name: "Xbox Wireless Controller", Driver: "xpadneo"},
+			want: false,
+		},
+		{
+			name: "known IDs, unknown driver",
+			info: DeviceInfo{VendorID: 0x045e, ProductID: 0x028e},
+			want: true,
+		},
 		{
 			name: "name xbox",
 			info: DeviceInfo{Name: "Xbox 360 Controller"},
@@ -41,3 +56,70 @@ func TestDeviceInfoIsXpad(t *testing.T) {
 		}
 	}
 }
+
+func TestEventKeyValuePredicates(t *testing.T) {
+	cases := []struct {
+		ev                         Event
+		press, release, autorepeat bool
+	}{
+		{ev: Event{Kind: EVKey, Code: BTNDPadUp, Value: KeyPressed}, press: true},
+		{ev: Event{Kind: EVKey, Code: BTNDPadUp, Value: KeyRepeated}, autorepeat: true},
+		{ev: Event{Kind: EVKey, Code: BTNDPadUp, Value: KeyReleased}, release: true},
+		{ev: Event{Kind: EVAbs, Code: ABSHat0Y, Value: 1}},
+	}
+
+	for _, tc := range cases {
+		if got := tc.ev.IsPress(); got != tc.press {
+			t.Fatalf("%+v: IsPress() = %v, want %v", tc.ev, got, tc.press)
+		}
+		if got := tc.ev.IsRelease(); got != tc.release {
+			t.Fatalf("%+v: IsRelease() = %v, want %v", tc.ev, got, tc.release)
+		}
+		if got := tc.ev.IsRepeat(); got != tc.autorepeat {
+			t.Fatalf("%+v: IsRepeat() = %v, want %v", tc.ev, got, tc.autorepeat)
+		}
+	}
+}
+
+func TestDeviceInfoStableID(t *testing.T) {
+	usb := USBInfo{SysfsPath: "/sys/devices/usb1/1-2", PortPath: "1-2", Serial: "0843E1B"}
+	cases := []struct {
+		name string
+		info DeviceInfo
+		want string
+	}{
+		{
+			name: "uniq wins",
+			info: DeviceInfo{VendorID: 0x045e, ProductID: 0x0b13, Uniq: "ac:8e:bd:01:02:03", USB: usb},
+			want: "045e:0b13/uniq:ac:8e:bd:01:02:03/if0",
+		},
+		{
+			name: "usb serial",
+			info: DeviceInfo{VendorID: 0x045e, ProductID: 0x028e, USB: usb},
+			want: "045e:028e/serial:0843E1B/if0",
+		},
+		{
+			name: "usb port",
+			info: DeviceInfo{VendorID: 0x045e, ProductID: 0x0719, USB: USBInfo{SysfsPath: "/sys/devices/usb1/1-3", PortPath: "1-3", InterfaceNumber: 2}},
+			want: "045e:0719/port:1-3/if2",
+		},
+		{
+			name: "phys sanitized",
+			info: DeviceInfo{Phys: "LNXPWRBN/button/input0"},
+			want: "0000:0000/phys:LNXPWRBN_button_input0",
+		},
+		{
+			name: "no identity",
+			info: DeviceInfo{VendorID: 0x045e, ProductID: 0x028e},
+			want: "",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.info.StableID(); got != tc.want {
+				t.Fatalf("StableID() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}